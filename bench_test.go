@@ -0,0 +1,188 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// The route sets for benchmarks are stored in the testdata directory in the
+// format of the route file:
+//
+//	github.json — GitHub API, 203 routes with up to four parameters;
+//	parse.json  — Parse API, 26 routes with one or two parameters;
+//	gplus.json  — Google+ API, 13 routes with one or two parameters;
+//	static.json — the files of the Go site, 157 static routes and two routes
+//	              with a catch-all parameter.
+
+// loadBench reads the route set and returns the tables of routes for each
+// method. The handler of each route is its pattern. The routes without a
+// method are added to the table with the empty method.
+func loadBench(b *testing.B, name string) ([]RouteEntry, map[string]*Table[string]) {
+	b.Helper()
+	file, err := os.Open("testdata/" + name + ".json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer file.Close()
+	entries, err := ReadRoutes(file)
+	if err != nil {
+		b.Fatal(err)
+	}
+	tables := make(map[string]*Table[string])
+	for _, entry := range entries {
+		table := tables[entry.Method]
+		if table == nil {
+			table = new(Table[string])
+			tables[entry.Method] = table
+		}
+		if err := table.Add(entry.Pattern, entry.Pattern); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return entries, tables
+}
+
+// benchPath returns the path for the pattern with the values of parameters.
+func benchPath(pattern string) string {
+	parts := splitter(pattern)
+	for i, part := range parts {
+		if strings.HasPrefix(part, NamedParamFlag) {
+			parts[i] = "value"
+		} else if strings.HasPrefix(part, CatchAllParamFlag) {
+			parts[i] = "some/long/path.go"
+		}
+	}
+	return PathDelimeter + strings.Join(parts, PathDelimeter)
+}
+
+// benchLookup measures the lookup of the paths with the specified method. The
+// route found must have the specified pattern: an empty string means that the
+// path must not be found.
+func benchLookup(b *testing.B, tables map[string]*Table[string], method, path, pattern string) {
+	table := tables[method]
+	if handler, _ := table.Lookup(path); handler != pattern {
+		b.Fatalf("bad route for %v: %q", path, handler)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		table.Lookup(path)
+	}
+}
+
+// benchAll measures the lookup of paths for all routes of the set.
+func benchAll(b *testing.B, entries []RouteEntry, tables map[string]*Table[string]) {
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = benchPath(entry.Pattern)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, entry := range entries {
+			tables[entry.Method].Lookup(paths[j])
+		}
+	}
+}
+
+func BenchmarkGitHub(b *testing.B) {
+	entries, tables := loadBench(b, "github")
+	for _, test := range []struct {
+		Name, Method, Path, Pattern string
+	}{
+		{"Static", "GET", "/user/repos", "/user/repos"},
+		{"Param", "GET", "/users/mdigger", "/users/:user"},
+		{"Params", "GET", "/repos/mdigger/router/pulls/42/files",
+			"/repos/:owner/:repo/pulls/:number/files"},
+		{"Params4", "GET", "/legacy/issues/search/mdigger/router/open/bug",
+			"/legacy/issues/search/:owner/:repository/:state/:keyword"},
+		{"Miss", "GET", "/repos/mdigger/router/pulls/42/unknown", ""},
+		{"MissLong", "GET", "/a/b/c/d/e/f/g/h", ""},
+	} {
+		b.Run(test.Name, func(b *testing.B) {
+			benchLookup(b, tables, test.Method, test.Path, test.Pattern)
+		})
+	}
+	b.Run("All", func(b *testing.B) {
+		benchAll(b, entries, tables)
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	entries, tables := loadBench(b, "parse")
+	for _, test := range []struct {
+		Name, Method, Path, Pattern string
+	}{
+		{"Static", "GET", "/1/users", "/1/users"},
+		{"Param", "GET", "/1/classes/go", "/1/classes/:className"},
+		{"Params", "GET", "/1/classes/go/123456789", "/1/classes/:className/:objectId"},
+		{"Miss", "GET", "/1/files/test", ""},
+	} {
+		b.Run(test.Name, func(b *testing.B) {
+			benchLookup(b, tables, test.Method, test.Path, test.Pattern)
+		})
+	}
+	b.Run("All", func(b *testing.B) {
+		benchAll(b, entries, tables)
+	})
+}
+
+func BenchmarkGPlus(b *testing.B) {
+	entries, tables := loadBench(b, "gplus")
+	for _, test := range []struct {
+		Name, Method, Path, Pattern string
+	}{
+		{"Static", "GET", "/people", "/people"},
+		{"Param", "GET", "/people/118051310819094153327", "/people/:userId"},
+		{"Params", "GET", "/people/118051310819094153327/activities/123456789",
+			"/people/:userId/activities/:collection"},
+		{"Miss", "GET", "/people/118051310819094153327/unknown/123456789", ""},
+	} {
+		b.Run(test.Name, func(b *testing.B) {
+			benchLookup(b, tables, test.Method, test.Path, test.Pattern)
+		})
+	}
+	b.Run("All", func(b *testing.B) {
+		benchAll(b, entries, tables)
+	})
+}
+
+func BenchmarkStatic(b *testing.B) {
+	entries, tables := loadBench(b, "static")
+	for _, test := range []struct {
+		Name, Path, Pattern string
+	}{
+		{"Root", "/", "/"},
+		{"Static", "/articles/wiki/final-noclosure.go", "/articles/wiki/final-noclosure.go"},
+		{"CatchAll", "/src/net/http/server.go", "/src/*filepath"},
+		{"Miss", "/articles/wiki/missing.go", ""},
+	} {
+		b.Run(test.Name, func(b *testing.B) {
+			benchLookup(b, tables, "", test.Path, test.Pattern)
+		})
+	}
+	b.Run("All", func(b *testing.B) {
+		benchAll(b, entries, tables)
+	})
+}
+
+func BenchmarkRouter(b *testing.B) {
+	entries, _ := loadBench(b, "github")
+	var r Router
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	for _, entry := range entries {
+		if _, err := r.HandleFunc(entry.Method, entry.Pattern, handler); err != nil {
+			b.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest("GET", "/repos/mdigger/router/pulls/42/files", nil)
+	w := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ServeHTTP(w, req)
+	}
+}
@@ -0,0 +1,67 @@
+// Command routergen generates Go code of a specialized matcher for the routes
+// declared in a route file or a Go source file. It's intended to be used with
+// go generate:
+//
+//	//go:generate go run github.com/mdigger/router/cmd/routergen -in routes.json -out routes.go
+//
+// The route file has the format described in router.ReadRoutes. In a Go source
+// file the calls of Add, Handle and HandleFunc with string literals are used as
+// route declarations. With the flag -urls only the functions building URLs of
+// routes are generated. See the package github.com/mdigger/router/gen for the
+// description of the generated code.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdigger/router"
+	"github.com/mdigger/router/gen"
+)
+
+func main() {
+	in := flag.String("in", "routes.json", "route `file` (.json) or Go source file (.go)")
+	out := flag.String("out", "", "output `file` (default stdout)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package `name` of the generated code")
+	urls := flag.Bool("urls", false, "generate only URL builder functions")
+	flag.Parse()
+	config := gen.Config{Package: *pkg, URLOnly: *urls}
+	if err := run(*in, *out, config); err != nil {
+		fmt.Fprintln(os.Stderr, "routergen:", err)
+		os.Exit(1)
+	}
+}
+
+// run reads the route declarations and writes the generated code.
+func run(in, out string, config gen.Config) error {
+	if config.Package == "" {
+		return fmt.Errorf("package name is not set")
+	}
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	var entries []router.RouteEntry
+	if strings.HasSuffix(in, ".go") {
+		entries, err = gen.ParseGo(in, data)
+	} else {
+		entries, err = router.ReadRoutes(bytes.NewReader(data))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+	var buf bytes.Buffer
+	config.Source = filepath.Base(in)
+	if err := gen.Generate(&buf, config, entries); err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = buf.WriteTo(os.Stdout)
+		return err
+	}
+	return os.WriteFile(out, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,172 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Coverage records the number of matches of each route. It's intended for
+// finding routes, which are never exercised by tests, and is enabled by the
+// Coverage method of Table or Router:
+//
+//	func TestMain(m *testing.M) {
+//		coverage := api.Coverage()
+//		code := m.Run()
+//		coverage.Report().WriteText(os.Stderr)
+//		os.Exit(code)
+//	}
+//
+// It's safe to use Coverage from several goroutines.
+type Coverage struct {
+	mu     sync.Mutex
+	hits   map[int]int            // количество совпадений по номеру пути
+	routes func() []CoverageRoute // возвращает список всех путей
+}
+
+// CoverageRoute describes the number of matches of the route.
+type CoverageRoute struct {
+	Method  string `json:"method,omitempty"` // the request method; empty for Table
+	Pattern string `json:"pattern"`          // the pattern of the route with the host
+	Hits    int    `json:"hits"`             // the number of matches
+
+	index int // порядковый номер пути в порядке добавления
+}
+
+// hit increases the number of matches of the route with the index.
+func (c *Coverage) hit(index int) {
+	c.mu.Lock()
+	c.hits[index]++
+	c.mu.Unlock()
+}
+
+// Reset sets the number of matches of all routes to zero.
+func (c *Coverage) Reset() {
+	c.mu.Lock()
+	c.hits = make(map[int]int)
+	c.mu.Unlock()
+}
+
+// Report returns the number of matches of all routes registered at the
+// moment, including routes added after enabling the recording.
+func (c *Coverage) Report() *CoverageReport {
+	routes := c.routes()
+	sort.Slice(routes, func(i, j int) bool { return routes[i].index < routes[j].index })
+	report := &CoverageReport{Total: len(routes), Routes: routes}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, route := range routes {
+		routes[i].Hits = c.hits[route.index]
+		if routes[i].Hits > 0 {
+			report.Covered++
+		}
+	}
+	return report
+}
+
+// Coverage enables recording of matches of routes by Lookup and Match and
+// returns the recorder. Repeated calls return the same recorder.
+func (r *Table[T]) Coverage() *Coverage {
+	if r.coverage == nil {
+		r.coverage = &Coverage{
+			hits: make(map[int]int),
+			routes: func() []CoverageRoute {
+				var routes []CoverageRoute
+				for _, record := range r.all() {
+					routes = append(routes, CoverageRoute{
+						Pattern: record.pattern(),
+						index:   record.index,
+					})
+				}
+				return routes
+			},
+		}
+	}
+	return r.coverage
+}
+
+// Coverage enables recording of matches of routes by ServeHTTP and returns the
+// recorder. Repeated calls return the same recorder.
+func (r *Router) Coverage() *Coverage {
+	if r.coverage == nil {
+		r.coverage = &Coverage{
+			hits: make(map[int]int),
+			routes: func() []CoverageRoute {
+				var routes []CoverageRoute
+				for _, paths := range r.methods {
+					for _, record := range paths.all() {
+						routes = append(routes, CoverageRoute{
+							Method:  record.handler.Method,
+							Pattern: record.handler.Host + record.handler.Pattern,
+							index:   record.handler.index,
+						})
+					}
+				}
+				return routes
+			},
+		}
+	}
+	return r.coverage
+}
+
+// CoverageReport describes the number of matches of routes.
+type CoverageReport struct {
+	Total   int             `json:"total"`   // the number of routes
+	Covered int             `json:"covered"` // the number of routes with matches
+	Routes  []CoverageRoute `json:"routes"`  // all routes in order of adding
+}
+
+// Uncovered returns the list of routes without matches in order of adding.
+func (r *CoverageReport) Uncovered() []CoverageRoute {
+	var routes []CoverageRoute
+	for _, route := range r.Routes {
+		if route.Hits == 0 {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// Percent returns the percent of routes with matches. If there are no routes,
+// it returns 100.
+func (r *CoverageReport) Percent() float64 {
+	if r.Total == 0 {
+		return 100
+	}
+	return float64(r.Covered) * 100 / float64(r.Total)
+}
+
+// WriteText writes the report as text: the summary and the list of routes
+// without matches.
+func (r *CoverageReport) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "routes covered: %d of %d (%.1f%%)\n",
+		r.Covered, r.Total, r.Percent()); err != nil {
+		return err
+	}
+	uncovered := r.Uncovered()
+	if len(uncovered) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, "not covered:"); err != nil {
+		return err
+	}
+	for _, route := range uncovered {
+		method := route.Method
+		if method == "" {
+			method = "*"
+		}
+		if _, err := fmt.Fprintf(w, "\t%s %s\n", method, route.Pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the report in JSON format.
+func (r *CoverageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(r)
+}
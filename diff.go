@@ -0,0 +1,297 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ChangeKind describes the kind of the change of the route.
+type ChangeKind uint8
+
+// The kinds of changes of routes.
+const (
+	RouteAdded    ChangeKind = iota // the route was added
+	RouteRemoved                    // the route was removed
+	RouteModified                   // the route was changed
+)
+
+// String returns the name of the kind of the change.
+func (k ChangeKind) String() string {
+	switch k {
+	case RouteAdded:
+		return "added"
+	case RouteRemoved:
+		return "removed"
+	case RouteModified:
+		return "modified"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", k)
+	}
+}
+
+// Change describes the change of the route between two route tables.
+type Change struct {
+	Kind       ChangeKind
+	OldMethod  string   // the method of the route in the old table
+	OldPattern string   // the pattern of the route in the old table
+	Method     string   // the method of the route in the new table
+	Pattern    string   // the pattern of the route in the new table
+	Details    []string // the description of changes of the modified route
+	// Breaking is set if requests of existing clients will not be processed
+	// after the change.
+	Breaking bool
+}
+
+// String returns the description of the change in one line: removed routes
+// are prefixed with "-", added with "+" and modified with "~".
+func (c Change) String() string {
+	var line string
+	switch c.Kind {
+	case RouteAdded:
+		line = "+ " + strings.TrimSpace(c.Method+" "+c.Pattern)
+	case RouteRemoved:
+		line = "- " + strings.TrimSpace(c.OldMethod+" "+c.OldPattern)
+	default:
+		line = "~ " + strings.TrimSpace(c.OldMethod+" "+c.OldPattern)
+		if len(c.Details) > 0 {
+			line += ": " + strings.Join(c.Details, ", ")
+		}
+	}
+	if c.Breaking {
+		line += " (breaking)"
+	}
+	return line
+}
+
+// RouteDiff describes the list of changes between two route tables.
+type RouteDiff []Change
+
+// Breaking returns true if there are changes breaking existing clients.
+func (d RouteDiff) Breaking() bool {
+	for _, change := range d {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the description of all changes, one per line.
+func (d RouteDiff) String() string {
+	lines := make([]string, len(d))
+	for i, change := range d {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffRoutes compares two lists of routes and returns the changes: removed
+// routes, changed routes and added routes, each group in order of the routes
+// in the lists. The lists can be obtained with Routes of Table or Router or
+// read from a file with ReadSavedRoutes or EntryRoutes.
+//
+// Routes are compared by the method, the host and the pattern, where the names
+// of parameters are ignored. A route with the same pattern and other names of
+// parameters or other metadata is reported as modified. If the route with the
+// same pattern is only in one of the lists with another method, it's reported
+// as modified with the changed method.
+//
+// A removed route or a route with the changed method is breaking, if the path
+// of the old route with sample values of parameters is not matched by the new
+// routes with the same method and the same host or for all hosts.
+//
+// The patterns are parsed with the lax syntax, as with WithLaxSyntax. If a
+// pattern can't be parsed, the error is returned.
+func DiffRoutes[A, B any](oldRoutes []Route[A], newRoutes []Route[B]) (RouteDiff, error) {
+	oldItems, err := diffItems(oldRoutes)
+	if err != nil {
+		return nil, err
+	}
+	newItems, err := diffItems(newRoutes)
+	if err != nil {
+		return nil, err
+	}
+	// таблицы новых путей для проверки совместимости по методам и хостам
+	tables := make(map[routeKey]*Table[int])
+	for i, item := range newItems {
+		key := routeKey{method: item.method, host: item.host}
+		if tables[key] == nil {
+			tables[key] = new(Table[int])
+		}
+		if err := tables[key].add(item.path, i, options{lax: true}); err != nil {
+			return nil, err
+		}
+	}
+	breaking := func(o *diffItem) bool {
+		path := sample(o.segments)
+		// запрос к хосту обрабатывается и путями для всех хостов
+		for _, host := range []string{o.host, ""} {
+			if table := tables[routeKey{method: o.method, host: host}]; table != nil {
+				if _, ok := table.Match(path); ok {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	var removed, modified, added RouteDiff
+	// пути с тем же методом и шаблоном
+	for _, o := range oldItems {
+		for _, n := range newItems {
+			if n.matched || n.method != o.method || n.shape != o.shape {
+				continue
+			}
+			o.matched, n.matched = true, true
+			change := Change{Kind: RouteModified, OldMethod: o.method, OldPattern: o.pattern,
+				Method: n.method, Pattern: n.pattern}
+			change.Details = append(change.Details, renames(o, n)...)
+			if formatMeta(o.meta) != formatMeta(n.meta) {
+				change.Details = append(change.Details, "meta changed")
+			}
+			if len(change.Details) > 0 {
+				modified = append(modified, change)
+			}
+			break
+		}
+	}
+	// пути с тем же шаблоном, но другим методом
+	for _, o := range oldItems {
+		if o.matched {
+			continue
+		}
+		for _, n := range newItems {
+			if n.matched || n.shape != o.shape {
+				continue
+			}
+			o.matched, n.matched = true, true
+			change := Change{Kind: RouteModified, OldMethod: o.method, OldPattern: o.pattern,
+				Method: n.method, Pattern: n.pattern, Breaking: breaking(o)}
+			change.Details = append(change.Details,
+				fmt.Sprintf("method %s changed to %s", o.method, n.method))
+			change.Details = append(change.Details, renames(o, n)...)
+			if formatMeta(o.meta) != formatMeta(n.meta) {
+				change.Details = append(change.Details, "meta changed")
+			}
+			modified = append(modified, change)
+			break
+		}
+		if !o.matched {
+			removed = append(removed, Change{Kind: RouteRemoved, OldMethod: o.method,
+				OldPattern: o.pattern, Breaking: breaking(o)})
+		}
+	}
+	for _, n := range newItems {
+		if !n.matched {
+			added = append(added, Change{Kind: RouteAdded, Method: n.method, Pattern: n.pattern})
+		}
+	}
+	return append(append(removed, modified...), added...), nil
+}
+
+// diffItem describes the route for the comparison.
+type diffItem struct {
+	method, host string
+	path         string    // шаблон пути без хоста
+	pattern      string    // шаблон пути с хостом для описания изменений
+	segments     []Segment // разобранные элементы шаблона
+	meta         Meta
+	shape        string // путь с хостом без имен параметров
+	matched      bool   // найдено соответствие в другом списке
+}
+
+// diffItems returns the list of routes for the comparison. The patterns are
+// parsed with the lax syntax.
+func diffItems[T any](routes []Route[T]) ([]*diffItem, error) {
+	items := make([]*diffItem, len(routes))
+	for i, route := range routes {
+		pattern, err := parsePattern(route.Pattern, options{lax: true})
+		if err != nil {
+			return nil, err
+		}
+		items[i] = &diffItem{method: route.Method, host: route.Host, path: route.Pattern,
+			pattern: route.Host + route.Pattern, segments: pattern.Segments,
+			meta: route.Meta, shape: route.Host + shape(pattern.Segments)}
+	}
+	return items, nil
+}
+
+// shape returns the pattern without the names of parameters.
+func shape(segments []Segment) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		switch segment.Kind {
+		case SegmentParam:
+			parts[i] = NamedParamFlag
+		case SegmentCatchAll:
+			parts[i] = CatchAllParamFlag
+		default:
+			parts[i] = segment.Value
+		}
+	}
+	return PathDelimeter + strings.Join(parts, PathDelimeter)
+}
+
+// sample returns the path for the pattern with sample values of parameters.
+func sample(segments []Segment) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		switch segment.Kind {
+		case SegmentParam:
+			parts[i] = "sample"
+		case SegmentCatchAll:
+			parts[i] = "sample/path"
+		default:
+			parts[i] = segment.Value
+		}
+	}
+	return PathDelimeter + strings.Join(parts, PathDelimeter)
+}
+
+// renames returns the descriptions of renamed parameters of the routes with
+// the same shape.
+func renames(o, n *diffItem) []string {
+	var list []string
+	for i, segment := range o.segments {
+		if segment.Kind != SegmentStatic && segment != n.segments[i] {
+			list = append(list, fmt.Sprintf("parameter %s renamed to %s",
+				segment, n.segments[i]))
+		}
+	}
+	return list
+}
+
+// ReadSavedRoutes reads the list of routes from the route table saved by
+// Table.Save. The key of the handler is used as the handler of the route.
+func ReadSavedRoutes(rd io.Reader) ([]Route[string], error) {
+	var saved savedTable
+	if err := json.NewDecoder(rd).Decode(&saved); err != nil {
+		return nil, err
+	}
+	if saved.Version != saveVersion {
+		return nil, fmt.Errorf("unsupported route table version: %d", saved.Version)
+	}
+	// восстанавливаем порядок добавления путей
+	sort.SliceStable(saved.Routes, func(i, j int) bool {
+		return saved.Routes[i].Index < saved.Routes[j].Index
+	})
+	routes := make([]Route[string], len(saved.Routes))
+	for i, route := range saved.Routes {
+		routes[i] = Route[string]{Pattern: route.Pattern, Handler: route.Handler, Meta: route.Meta}
+	}
+	return routes, nil
+}
+
+// EntryRoutes returns the list of routes for the entries of the route file.
+// The target name is used as the handler of the route.
+func EntryRoutes(entries []RouteEntry) []Route[string] {
+	routes := make([]Route[string], len(entries))
+	for i, entry := range entries {
+		routes[i] = Route[string]{Method: entry.Method, Pattern: entry.Pattern,
+			Handler: entry.Target, Meta: entry.Meta}
+	}
+	return routes
+}
@@ -0,0 +1,176 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteRoutes writes the list of routes as an aligned table with the method,
+// pattern, handler name and metadata of each route. The name of a function
+// handler is obtained via reflection. Routes without a method are shown with
+// the method "*", the host of the route is shown before its pattern.
+func WriteRoutes[T any](w io.Writer, routes []Route[T]) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATTERN\tHANDLER\tMETA")
+	for _, route := range routes {
+		method := route.Method
+		if method == "" {
+			method = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", method, route.Host+route.Pattern,
+			handlerName(route.Handler), formatMeta(route.Meta))
+	}
+	return tw.Flush()
+}
+
+// WriteTree writes the registered routes as a tree of path elements. Each
+// route is marked with its number in order of checking by Lookup, the kind of
+// the route and the name of the handler. Static routes are checked first, then
+// routes with parameters from the longest to the shortest.
+func (r *Table[T]) WriteTree(w io.Writer) error {
+	return writeTree(w, r.ordered())
+}
+
+// WriteTree writes the tree of routes for each request method in alphabetical
+// order of methods. The routes for separate hosts are written after the routes
+// for all hosts with the same method.
+func (r *Router) WriteTree(w io.Writer) error {
+	keys := make([]routeKey, 0, len(r.methods))
+	for key := range r.methods {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].host < keys[j].host
+	})
+	for _, key := range keys {
+		if _, err := fmt.Fprintln(w, strings.TrimSpace(key.method+" "+key.host)); err != nil {
+			return err
+		}
+		if err := writeTree(w, r.methods[key].ordered()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DebugHandler returns the HTTP handler, which responds with the table of all
+// registered routes. With the query parameter "tree" the tree of routes is
+// returned instead.
+func (r *Router) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if _, ok := req.URL.Query()["tree"]; ok {
+			r.WriteTree(w)
+			return
+		}
+		WriteRoutes(w, r.Routes())
+	})
+}
+
+// treeNode describes an element of the tree of routes.
+type treeNode struct {
+	part     string      // элемент пути
+	routes   []string    // описания путей, заканчивающихся на этом элементе
+	children []*treeNode // вложенные элементы в порядке появления
+}
+
+// child returns the nested element with the specified name, creating it if
+// necessary.
+func (n *treeNode) child(part string) *treeNode {
+	for _, child := range n.children {
+		if child.part == part {
+			return child
+		}
+	}
+	child := &treeNode{part: part}
+	n.children = append(n.children, child)
+	return child
+}
+
+// writeTree builds the tree from the records and writes it.
+func writeTree[T any](w io.Writer, records []*record[T]) error {
+	root := new(treeNode)
+	for i, record := range records {
+		node := root
+		for _, part := range record.parts {
+			node = node.child(part)
+		}
+		node.routes = append(node.routes, fmt.Sprintf("[%d %s] %s",
+			i+1, record.kind(), handlerName(record.handler)))
+	}
+	return root.write(w, "")
+}
+
+// write writes the nested elements of the tree with the specified indent.
+func (n *treeNode) write(w io.Writer, indent string) error {
+	for i, child := range n.children {
+		branch, next := "├── ", "│   "
+		if i == len(n.children)-1 {
+			branch, next = "└── ", "    "
+		}
+		line := indent + branch + PathDelimeter + child.part
+		if len(child.routes) > 0 {
+			line += "  " + strings.Join(child.routes, ", ")
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		if err := child.write(w, indent+next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handlerName returns the name of the handler: for functions it's the name of
+// the function, for simple values the value itself and for other handlers the
+// name of their type.
+func handlerName(handler interface{}) string {
+	// для путей HTTP роутера выводим имя исходного обработчика
+	if endpoint, ok := handler.(*Endpoint); ok {
+		handler = endpoint.handler
+	}
+	if handler == nil {
+		return "<nil>"
+	}
+	value := reflect.ValueOf(handler)
+	switch value.Kind() {
+	case reflect.Func:
+		if f := runtime.FuncForPC(value.Pointer()); f != nil {
+			return f.Name()
+		}
+		return value.Type().String()
+	case reflect.String:
+		return fmt.Sprintf("%q", handler)
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(handler)
+	}
+	if stringer, ok := handler.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return value.Type().String()
+}
+
+// formatMeta returns the metadata as a list of key=value pairs sorted by key.
+func formatMeta(meta Meta) string {
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		keys[i] = fmt.Sprintf("%s=%v", key, meta[key])
+	}
+	return strings.Join(keys, " ")
+}
@@ -0,0 +1,170 @@
+package router
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// refRoute describes the route for the brute-force reference matcher.
+type refRoute struct {
+	parts    []string
+	priority int
+	index    int
+}
+
+// refMatch checks the path against the route without any indexes and returns
+// the values of its parameters.
+func refMatch(route, path []string) (Params, bool) {
+	last := len(route) - 1
+	catchAll := strings.HasPrefix(route[last], CatchAllParamFlag)
+	if len(path) < len(route) || (!catchAll && len(path) != len(route)) {
+		return nil, false
+	}
+	var params Params
+	for i, part := range route {
+		switch {
+		case strings.HasPrefix(part, NamedParamFlag):
+			params = append(params, Param{Key: part[len(NamedParamFlag):], Value: path[i]})
+		case strings.HasPrefix(part, CatchAllParamFlag):
+			params = append(params, Param{Key: part[len(CatchAllParamFlag):],
+				Value: strings.Join(path[i:], PathDelimeter)})
+		case part != path[i]:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// refBetter returns true if the route a must be selected instead of the route b
+// when both of them match the path.
+func refBetter(a, b refRoute) bool {
+	kind := func(r refRoute) (bool, bool, int) {
+		var named int
+		var catchAll bool
+		for _, part := range r.parts {
+			if strings.HasPrefix(part, NamedParamFlag) {
+				named++
+			} else if strings.HasPrefix(part, CatchAllParamFlag) {
+				catchAll = true
+			}
+		}
+		return named == 0 && !catchAll, catchAll, named
+	}
+	aStatic, aCatchAll, aNamed := kind(a)
+	bStatic, bCatchAll, bNamed := kind(b)
+	switch {
+	case aStatic != bStatic:
+		return aStatic
+	case aStatic:
+		return a.index > b.index // статический путь заменяет добавленный раньше
+	case len(a.parts) != len(b.parts):
+		return len(a.parts) > len(b.parts)
+	case aCatchAll != bCatchAll:
+		return !aCatchAll
+	case a.priority != b.priority:
+		return a.priority > b.priority
+	case aNamed != bNamed:
+		return aNamed < bNamed
+	}
+	for i := range a.parts {
+		if as, bs := isStatic(a.parts[i]), isStatic(b.parts[i]); as != bs {
+			return as
+		}
+	}
+	return a.index < b.index
+}
+
+func FuzzLookup(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"/users\n/users/:id\n/users/:id/posts\n/files/*name", "/users/42/posts"},
+		{"/:a/b\n/a/:b\n!/:a/:b", "/a/b"},
+		{"/*\n/:\n/a/*rest", "/a/"},
+		{"/\n/*path", ""},
+		{"/a/:x/*y\n/a/:x/:y/:z\n/a/b/*c", "/a/b/c/d/e"},
+		{"/a/*b/c\n/x/:y", "//x//"},
+		{"/a/:x", "/a/b" + strings.Repeat("/", 1<<16)},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, patterns, url string) {
+		var r Table[int]
+		var routes []refRoute
+		for _, pattern := range strings.Split(patterns, "\n") {
+			// путь с восклицательным знаком добавляется с приоритетом
+			var priority int
+			if strings.HasPrefix(pattern, "!") {
+				pattern, priority = pattern[1:], 1
+			}
+			index := len(routes)
+			// имена параметров не проверяем, чтобы проверить и такие пути
+			if err := r.Add(pattern, index, WithPriority(priority), WithLaxSyntax()); err != nil {
+				continue
+			}
+			routes = append(routes, refRoute{parts: splitter(pattern),
+				priority: priority, index: index})
+		}
+		// выбираем лучший путь перебором всех путей
+		var (
+			want   *refRoute
+			params Params
+		)
+		path := splitter(url)
+		for i, route := range routes {
+			if values, ok := refMatch(route.parts, path); ok &&
+				(want == nil || refBetter(route, *want)) {
+				want, params = &routes[i], values
+			}
+		}
+		match, ok := r.Match(url)
+		if want == nil {
+			if ok {
+				t.Fatalf("unexpected match %q for %q", match.Pattern, url)
+			}
+			return
+		}
+		if !ok {
+			t.Fatalf("no match for %q, want %q", url, want.parts)
+		}
+		if match.Handler != want.index {
+			t.Fatalf("bad match for %q: %q, want %q", url, match.Pattern, want.parts)
+		}
+		// имена параметров должны соответствовать шаблону пути
+		var names []string
+		for _, part := range match.Parts {
+			if strings.HasPrefix(part, NamedParamFlag) {
+				names = append(names, part[len(NamedParamFlag):])
+			} else if strings.HasPrefix(part, CatchAllParamFlag) {
+				names = append(names, part[len(CatchAllParamFlag):])
+			}
+		}
+		var keys []string
+		for _, param := range match.Params {
+			keys = append(keys, param.Key)
+		}
+		if !reflect.DeepEqual(keys, names) {
+			t.Fatalf("bad param names for %q: %q, want %q", url, keys, names)
+		}
+		if !reflect.DeepEqual(match.Params, params) {
+			t.Fatalf("bad params for %q: %v, want %v", url, match.Params, params)
+		}
+	})
+}
+
+func FuzzServeMux(f *testing.F) {
+	for _, seed := range []string{"/users/{id}", "/files/{path...}", "/{$}", "/static/", "/{", "/a/{}/b"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, pattern string) {
+		var r Table[int]
+		if err := r.Add(pattern, 1, WithServeMux()); err != nil {
+			return
+		}
+		// добавленный путь в синтаксисе роутера должен разбираться без ошибок
+		route := r.Routes()[0].Pattern
+		if _, err := parsePattern(route, options{serveMux: true}); err != nil {
+			t.Fatalf("bad converted pattern %q: %v", route, err)
+		}
+		r.Lookup(pattern)
+	})
+}
@@ -0,0 +1,569 @@
+// Package gen generates Go code of a specialized matcher for a fixed set of
+// routes. The generated matcher returns the same results as Table.Lookup, but
+// instead of interpreting the route table at runtime it compares the path
+// elements with string constants.
+//
+// For each route the generated code contains a constant of the type Route and
+// the function building its URL from the escaped parameters, for each route
+// with parameters also the structure with typed parameters.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mdigger/router"
+)
+
+// Config describes the settings of code generation.
+type Config struct {
+	Package string // the name of the package of the generated code
+	Source  string // the name of the source file for the header comment
+	URLOnly bool   // generate only the URL builder functions
+}
+
+// route describes the route of the generated code.
+type route struct {
+	router.RouteEntry
+	name   string   // имя пути в Go
+	url    string   // имя функции построения URL или пустая строка
+	parts  []string // элементы пути
+	params []param  // параметры пути
+}
+
+// param describes the parameter of the route.
+type param struct {
+	key      string // имя параметра
+	field    string // имя поля структуры в Go
+	index    int    // номер элемента пути
+	catchAll bool   // параметр забирает весь оставшийся путь
+}
+
+// Generate writes the Go code of the matcher for the routes. The name of the
+// route in the generated code is taken from the metadata with the key "name"
+// or, if not set, is built from the static elements of its pattern.
+//
+// For each pattern the function building the path with escaped parameters is
+// generated: for example, for the pattern /repos/:owner/:repo/pulls it's
+// ReposPullsURL(owner, repo string) string. If the URLOnly setting is set,
+// only these functions are generated.
+//
+// If routes have methods, the generated Lookup function selects the route by
+// the method and path, like Router. Otherwise it selects the route by path
+// only, like Table. Mixing routes with and without methods is not allowed.
+func Generate(w io.Writer, config Config, entries []router.RouteEntry) error {
+	routes, err := prepare(entries)
+	if err != nil {
+		return err
+	}
+	g := &generator{buf: new(bytes.Buffer), routes: routes}
+	if err := g.generate(config); err != nil {
+		return err
+	}
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format generated code: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// prepare checks the routes and selects the names for them.
+func prepare(entries []router.RouteEntry) ([]*route, error) {
+	routes := make([]*route, len(entries))
+	used := map[string]bool{"None": true} // RouteNone уже используется
+	usedURL := make(map[string]bool)      // имена функций построения URL
+	patterns := make(map[string]bool)     // пути, для которых они уже есть
+	withMethod := len(entries) > 0 && entries[0].Method != ""
+	for i, entry := range entries {
+		if (entry.Method != "") != withMethod {
+			return nil, fmt.Errorf("%s: routes with and without methods are mixed", entry.Pattern)
+		}
+		// проверяем путь тем же способом, что и при добавлении
+		var table router.Paths
+		if err := table.Add(entry.Pattern, i); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Pattern, err)
+		}
+		r := &route{
+			RouteEntry: entry,
+			parts:      strings.Split(strings.TrimPrefix(entry.Pattern, "/"), "/"),
+		}
+		var static []string
+		fields := make(map[string]bool)
+		for j, part := range r.parts {
+			var p param
+			switch {
+			case strings.HasPrefix(part, router.NamedParamFlag):
+				p = param{key: strings.TrimPrefix(part, router.NamedParamFlag), index: j}
+			case strings.HasPrefix(part, router.CatchAllParamFlag):
+				p = param{key: strings.TrimPrefix(part, router.CatchAllParamFlag), index: j, catchAll: true}
+			default:
+				static = append(static, part)
+				continue
+			}
+			// имена полей структуры должны быть уникальными и не совпадать
+			// с именем ее метода URL
+			field := identifier(p.key, "Param")
+			if field == "URL" {
+				field += "Param"
+			}
+			p.field = field
+			for n := 2; fields[p.field]; n++ {
+				p.field = field + strconv.Itoa(n)
+			}
+			fields[p.field] = true
+			r.params = append(r.params, p)
+		}
+		// выбираем уникальное имя пути
+		var name string
+		if value, ok := entry.Meta["name"].(string); ok && value != "" {
+			name = identifier(value, "Route")
+		}
+		base := identifier(strings.Join(static, " "), "Root")
+		var method string
+		if withMethod {
+			method = identifier(strings.ToLower(entry.Method), "")
+		}
+		r.name = choose(used, name, method+base, r.params)
+		// функция построения URL не зависит от метода, поэтому создается
+		// только одна для каждого пути
+		if !patterns[entry.Pattern] {
+			patterns[entry.Pattern] = true
+			r.url = choose(usedURL, name, base, r.params)
+		}
+		routes[i] = r
+	}
+	return routes, nil
+}
+
+// choose returns the first unused name from the explicitly specified name, the
+// base name and the base name with the names of parameters. If all of them are
+// used, a number is added to the base name.
+func choose(used map[string]bool, name, base string, params []param) string {
+	candidates := []string{name, base}
+	if len(params) > 0 {
+		var keys []string
+		for _, p := range params {
+			keys = append(keys, p.field)
+		}
+		candidates = append(candidates, base+"By"+strings.Join(keys, ""))
+	}
+	for _, candidate := range candidates {
+		if candidate != "" && !used[candidate] {
+			used[candidate] = true
+			return candidate
+		}
+	}
+	for n := 2; ; n++ {
+		if candidate := base + strconv.Itoa(n); !used[candidate] {
+			used[candidate] = true
+			return candidate
+		}
+	}
+}
+
+// generator writes the generated code.
+type generator struct {
+	buf     *bytes.Buffer
+	routes  []*route
+	url     bool // используется пакет net/url
+	strings bool // используется пакет strings
+}
+
+// printf writes the formatted line of code.
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.buf, format, args...)
+	g.buf.WriteByte('\n')
+}
+
+// generate writes the whole generated file.
+func (g *generator) generate(config Config) error {
+	// сначала формируем код, чтобы узнать, какие пакеты используются
+	if !config.URLOnly {
+		g.generateRoutes()
+		if err := g.generateLookup(); err != nil {
+			return err
+		}
+		g.generateParams()
+		g.strings = true
+	}
+	g.generateURLs()
+	body := g.buf
+	g.buf = new(bytes.Buffer)
+	if config.Source != "" {
+		g.printf("// Code generated by routergen from %s. DO NOT EDIT.", config.Source)
+	} else {
+		g.printf("// Code generated by routergen. DO NOT EDIT.")
+	}
+	g.printf("")
+	g.printf("package %s", config.Package)
+	g.printf("")
+	g.printf("import (")
+	if g.url {
+		g.printf("%q", "net/url")
+	}
+	if g.strings {
+		g.printf("%q", "strings")
+	}
+	if !config.URLOnly {
+		g.printf("")
+		g.printf("%q", "github.com/mdigger/router")
+	}
+	g.printf(")")
+	g.printf("")
+	body.WriteTo(g.buf)
+	return nil
+}
+
+// generateRoutes writes the constants of routes.
+func (g *generator) generateRoutes() {
+	g.printf("// Route identifies the route.")
+	g.printf("type Route int")
+	g.printf("")
+	g.printf("// The routes in order of declaration.")
+	g.printf("const (")
+	g.printf("RouteNone Route = iota // no route found")
+	for _, r := range g.routes {
+		g.printf("Route%s // %s", r.name, strings.TrimSpace(r.Method+" "+r.Pattern))
+	}
+	g.printf(")")
+	g.printf("")
+	g.printf("// routePatterns contains the patterns of routes.")
+	g.printf("var routePatterns = [...]string{")
+	g.printf("RouteNone: \"\",")
+	for _, r := range g.routes {
+		g.printf("Route%s: %q,", r.name, r.Pattern)
+	}
+	g.printf("}")
+	g.printf("")
+	g.printf("// Pattern returns the pattern of the route.")
+	g.printf("func (r Route) Pattern() string {")
+	g.printf("if r < 0 || int(r) >= len(routePatterns) {")
+	g.printf("return \"\"")
+	g.printf("}")
+	g.printf("return routePatterns[r]")
+	g.printf("}")
+	g.printf("")
+}
+
+// generateLookup writes the Lookup function.
+func (g *generator) generateLookup() error {
+	if len(g.routes) == 0 || g.routes[0].Method == "" {
+		g.printf("// Lookup returns the route for the path and the values of its named")
+		g.printf("// parameters. If no route is found, it returns RouteNone.")
+		g.printf("func Lookup(path string) (Route, router.Params) {")
+		g.printf("return lookup(path)")
+		g.printf("}")
+		g.printf("")
+		return g.generateMatcher("lookup", g.routes)
+	}
+	// группируем пути по методам
+	methods := make(map[string][]*route)
+	var names []string
+	for _, r := range g.routes {
+		if methods[r.Method] == nil {
+			names = append(names, r.Method)
+		}
+		methods[r.Method] = append(methods[r.Method], r)
+	}
+	sort.Strings(names)
+	g.printf("// Lookup returns the route for the request method and path and the values")
+	g.printf("// of its named parameters. If no route is found, it returns RouteNone.")
+	g.printf("func Lookup(method, path string) (Route, router.Params) {")
+	g.printf("switch method {")
+	for _, method := range names {
+		g.printf("case %q:", method)
+		g.printf("return lookup%s(path)", identifier(strings.ToLower(method), "Method"))
+	}
+	g.printf("}")
+	g.printf("return RouteNone, nil")
+	g.printf("}")
+	g.printf("")
+	for _, method := range names {
+		if err := g.generateMatcher("lookup"+identifier(strings.ToLower(method), "Method"),
+			methods[method]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateMatcher writes the function selecting one of the routes for the path
+// in the same order as Table.Lookup does.
+func (g *generator) generateMatcher(name string, routes []*route) error {
+	// порядок проверки путей получаем из таблицы
+	var table router.Table[*route]
+	for _, r := range routes {
+		if err := table.Add(r.Pattern, r); err != nil {
+			return fmt.Errorf("%s: %w", r.Pattern, err)
+		}
+	}
+	ordered := table.Ordered()
+	g.printf("// %s selects the route for the path.", name)
+	g.printf("func %s(path string) (Route, router.Params) {", name)
+	g.printf("path = strings.TrimPrefix(path, \"/\")")
+	// статические пути проверяются сразу по полному пути
+	var static, levels []*route
+	for _, item := range ordered {
+		if len(item.Handler.params) == 0 {
+			static = append(static, item.Handler)
+		} else {
+			levels = append(levels, item.Handler)
+		}
+	}
+	if len(static) > 0 {
+		g.printf("switch path {")
+		for _, r := range static {
+			g.printf("case %q:", strings.Join(r.parts, "/"))
+			g.printf("return Route%s, nil", r.name)
+		}
+		g.printf("}")
+	}
+	if len(levels) > 0 {
+		g.printf("parts := strings.Split(path, \"/\")")
+		g.printf("n := len(parts)")
+	}
+	// пути с параметрами сгруппированы по количеству элементов, а пути с
+	// catch-all параметром в каждой группе проверяются последними
+	for len(levels) > 0 {
+		level := len(levels[0].parts)
+		var count int
+		for count < len(levels) && len(levels[count].parts) == level {
+			count++
+		}
+		var exact, catchAll []*route
+		for _, r := range levels[:count] {
+			if r.params[len(r.params)-1].catchAll {
+				catchAll = append(catchAll, r)
+			} else {
+				exact = append(exact, r)
+			}
+		}
+		// после пути без статических элементов остальные пути этой группы
+		// уже никогда не будут выбраны
+		if len(exact) > 0 {
+			g.printf("if n == %d {", level)
+			for _, r := range exact {
+				if !g.generateCheck(r) {
+					break
+				}
+			}
+			g.printf("}")
+		}
+		if len(catchAll) > 0 {
+			g.printf("if n >= %d {", level)
+			for _, r := range catchAll {
+				if !g.generateCheck(r) {
+					break
+				}
+			}
+			g.printf("}")
+		}
+		levels = levels[count:]
+	}
+	g.printf("return RouteNone, nil")
+	g.printf("}")
+	g.printf("")
+	return nil
+}
+
+// generateCheck writes the comparison of the path elements with the static
+// elements of the route. Returns false if the route has no static elements and
+// is selected without any conditions.
+func (g *generator) generateCheck(r *route) bool {
+	var conditions []string
+	for i, part := range r.parts {
+		if !strings.HasPrefix(part, router.NamedParamFlag) &&
+			!strings.HasPrefix(part, router.CatchAllParamFlag) {
+			conditions = append(conditions, fmt.Sprintf("parts[%d] == %q", i, part))
+		}
+	}
+	values := make([]string, len(r.params))
+	for i, p := range r.params {
+		value := fmt.Sprintf("parts[%d]", p.index)
+		if p.catchAll {
+			value = fmt.Sprintf("strings.Join(parts[%d:], \"/\")", p.index)
+		}
+		values[i] = fmt.Sprintf("{Key: %q, Value: %s}", p.key, value)
+	}
+	result := fmt.Sprintf("return Route%s, router.Params{%s}", r.name, strings.Join(values, ", "))
+	g.printf("// %s", r.Pattern)
+	if len(conditions) == 0 {
+		g.printf("%s", result)
+		return false
+	}
+	g.printf("if %s {", strings.Join(conditions, " && "))
+	g.printf("%s", result)
+	g.printf("}")
+	return true
+}
+
+// generateParams writes the structures with typed parameters of routes.
+func (g *generator) generateParams() {
+	// функции построения URL создаются только для первого из путей с
+	// одинаковым шаблоном
+	urls := make(map[string]string)
+	for _, r := range g.routes {
+		if r.url != "" {
+			urls[r.Pattern] = r.url
+		}
+	}
+	for _, r := range g.routes {
+		if len(r.params) == 0 {
+			continue
+		}
+		typeName := r.name + "Params"
+		g.printf("// %s contains the parameters of the route %s.", typeName, r.Pattern)
+		g.printf("type %s struct {", typeName)
+		for _, p := range r.params {
+			g.printf("%s string // %s", p.field, p.key)
+		}
+		g.printf("}")
+		g.printf("")
+		g.printf("// New%s returns the parameters of the route %s.", typeName, r.Pattern)
+		g.printf("func New%s(params router.Params) %s {", typeName, typeName)
+		g.printf("return %s{", typeName)
+		for _, p := range r.params {
+			g.printf("%s: params.Get(%q),", p.field, p.key)
+		}
+		g.printf("}")
+		g.printf("}")
+		g.printf("")
+		args := make([]string, len(r.params))
+		for i, p := range r.params {
+			args[i] = "p." + p.field
+		}
+		g.printf("// URL returns the path of the route %s with escaped parameters.", r.Pattern)
+		g.printf("func (p %s) URL() string {", typeName)
+		g.printf("return %sURL(%s)", urls[r.Pattern], strings.Join(args, ", "))
+		g.printf("}")
+		g.printf("")
+	}
+}
+
+// generateURLs writes the functions building the paths of routes.
+func (g *generator) generateURLs() {
+	var escape bool
+	for _, r := range g.routes {
+		if r.url == "" {
+			continue
+		}
+		// имена аргументов не должны совпадать с именами пакетов и функций
+		args := make([]string, len(r.params))
+		for i, p := range r.params {
+			args[i] = unexported(p.field)
+			switch args[i] {
+			case "url", "strings", "escapePath":
+				args[i] += "Param"
+			default:
+				if token.IsKeyword(args[i]) {
+					args[i] += "Param"
+				}
+			}
+		}
+		var expr []string
+		var static []string
+		for i, part := range r.parts {
+			var value string
+			for j, p := range r.params {
+				if p.index != i {
+					continue
+				}
+				g.url = true
+				if p.catchAll {
+					value = "escapePath(" + args[j] + ")"
+					escape = true
+				} else {
+					value = "url.PathEscape(" + args[j] + ")"
+				}
+			}
+			if value == "" {
+				static = append(static, part)
+				continue
+			}
+			static = append(static, "")
+			expr = append(expr, strconv.Quote("/"+strings.Join(static, "/")), value)
+			static = nil
+		}
+		if static != nil {
+			expr = append(expr, strconv.Quote("/"+strings.Join(static, "/")))
+		}
+		signature := ""
+		if len(args) > 0 {
+			signature = strings.Join(args, ", ") + " string"
+		}
+		if len(args) > 0 {
+			g.printf("// %sURL returns the path of the route %s with escaped parameters.", r.url, r.Pattern)
+		} else {
+			g.printf("// %sURL returns the path of the route %s.", r.url, r.Pattern)
+		}
+		g.printf("func %sURL(%s) string {", r.url, signature)
+		g.printf("return %s", strings.Join(expr, " + "))
+		g.printf("}")
+		g.printf("")
+	}
+	if escape {
+		g.strings = true
+		g.printf("// escapePath escapes each element of the path.")
+		g.printf("func escapePath(path string) string {")
+		g.printf("parts := strings.Split(path, \"/\")")
+		g.printf("for i, part := range parts {")
+		g.printf("parts[i] = url.PathEscape(part)")
+		g.printf("}")
+		g.printf("return strings.Join(parts, \"/\")")
+		g.printf("}")
+	}
+}
+
+// unexported returns the identifier with the lower case of the first word:
+// ID -> id, ClientID -> clientID, IDToken -> idToken.
+func unexported(s string) string {
+	runes := []rune(s)
+	n := 0 // количество заглавных букв в начале
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	// последняя заглавная буква перед строчной относится к следующему слову
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// initialisms contains the words written in upper case in Go identifiers.
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "json": true,
+	"sha": true, "sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// identifier returns the exported Go identifier built from the words of the
+// string. If there are no letters in the string, it returns the default name.
+func identifier(s, def string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var name strings.Builder
+	for _, word := range words {
+		if initialisms[strings.ToLower(word)] {
+			name.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		name.WriteString(string(runes))
+	}
+	if name.Len() == 0 || !unicode.IsLetter([]rune(name.String())[0]) {
+		return def + name.String()
+	}
+	return name.String()
+}
@@ -0,0 +1,197 @@
+package gen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mdigger/router"
+)
+
+func TestGenerate(t *testing.T) {
+	// сгенерированный код примера должен соответствовать описанию путей
+	data, err := os.ReadFile("internal/example/routes.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := router.ReadRoutes(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := Generate(&buf, Config{Package: "example", Source: "routes.json"}, entries); err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("internal/example/routes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != string(want) {
+		t.Error("generated code is out of date: run go generate")
+	}
+}
+
+func TestGenerateMethods(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Generate(&buf, Config{Package: "api"}, []router.RouteEntry{
+		{Method: "GET", Pattern: "/users"},
+		{Method: "POST", Pattern: "/users"},
+		{Method: "GET", Pattern: "/users/:id"},
+		{Method: "GET", Pattern: "/none"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		"// Code generated by routergen. DO NOT EDIT.",
+		"func Lookup(method, path string) (Route, router.Params) {",
+		"\tcase \"POST\":\n\t\treturn lookupPost(path)",
+		"RouteGetUsers ",
+		"RoutePostUsers ",
+		"RouteGetUsersByID ",
+		"RouteGetNone ",
+		"type GetUsersByIDParams struct {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, src)
+		}
+	}
+
+	for _, entries := range [][]router.RouteEntry{
+		{{Method: "GET", Pattern: "/users"}, {Pattern: "/users"}},
+		{{Pattern: "/files/*name/test"}},
+	} {
+		if err := Generate(&buf, Config{Package: "api"}, entries); err == nil {
+			t.Errorf("bad routes generated: %v", entries)
+		}
+	}
+}
+
+func TestGenerateURLOnly(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Generate(&buf, Config{Package: "api", URLOnly: true}, []router.RouteEntry{
+		{Method: "GET", Pattern: "/users/:id"},
+		{Method: "POST", Pattern: "/users/:id"},
+		{Method: "GET", Pattern: "/types/:type/:url"},
+		{Method: "GET", Pattern: "/files/*path"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		"func UsersURL(id string) string {",
+		"func TypesURL(typeParam, urlParam string) string {",
+		"return \"/files/\" + escapePath(path)",
+		"\"net/url\"",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, src)
+		}
+	}
+	for _, skip := range []string{"func Lookup", "mdigger/router", "Params struct", "UsersURL2"} {
+		if strings.Contains(src, skip) {
+			t.Errorf("generated code contains %q:\n%s", skip, src)
+		}
+	}
+}
+
+func TestParseGo(t *testing.T) {
+	entries, err := ParseGo("routes.go", []byte(`package api
+
+func routes() {
+	var paths router.Paths
+	paths.Add("/users", listUsers)
+	paths.Add(prefix+"/skip", skip)
+	var r router.Router
+	r.HandleFunc("GET", "/users/:id", api.getUser)
+	r.Handle(http.MethodDelete, "/users/:id", deleteUser)
+	r.Handle(method, "/skip", skip)
+	r.Handle(other.MethodGet, "/skip", skip)
+	r.Handle("GET", "/skip")
+	paths.Add("/skip")
+	fmt.Println("/skip")
+	w.Header().Add("Content-Type", "text/plain")
+	q := url.Values{}
+	q.Add("page", "1")
+	req.Header.Add("Accept", "*/*")
+	mux.Handle("GET", "skip", skip)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []router.RouteEntry{
+		{Pattern: "/users", Target: "listUsers"},
+		{Method: "GET", Pattern: "/users/:id", Target: "api.getUser"},
+		{Method: "DELETE", Pattern: "/users/:id", Target: "deleteUser"},
+	}; !reflect.DeepEqual(entries, want) {
+		t.Errorf("bad entries: %+v", entries)
+	}
+	if _, err := ParseGo("routes.go", []byte("package api\n")); err == nil {
+		t.Error("no routes error expected")
+	}
+	if _, err := ParseGo("routes.go", []byte("package")); err == nil {
+		t.Error("syntax error expected")
+	}
+}
+
+func TestUnexported(t *testing.T) {
+	for _, test := range []struct {
+		Name, Ident string
+	}{
+		{"ID", "id"},
+		{"ClientID", "clientID"},
+		{"IDToken", "idToken"},
+		{"Owner", "owner"},
+	} {
+		if ident := unexported(test.Name); ident != test.Ident {
+			t.Errorf("bad unexported identifier %q: %v", test.Name, ident)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	for _, test := range []struct {
+		Name, Ident string
+	}{
+		{"user_id", "UserID"},
+		{"mx-name", "MxName"},
+		{"2fa", "Param2fa"},
+		{"", "Param"},
+		{"имя", "Имя"},
+	} {
+		if ident := identifier(test.Name, "Param"); ident != test.Ident {
+			t.Errorf("bad identifier %q: %v", test.Name, ident)
+		}
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Generate(&buf, Config{Package: "api"}, []router.RouteEntry{
+		{Method: "GET", Pattern: "/x/:type/:strings/:url/:escapePath"},
+		{Method: "GET", Pattern: "/files/:URL/*url"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// сгенерированный код должен компилироваться
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := config.Check("api", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("generated code does not compile: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "return FilesURL(p.URLParam, p.URLParam2)") {
+		t.Errorf("bad field for the parameter url:\n%s", buf.String())
+	}
+}
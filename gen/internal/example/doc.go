@@ -0,0 +1,6 @@
+// Package example contains the matcher generated for the test set of routes.
+// The tests of the package check that it selects the same routes as
+// router.Table.
+package example
+
+//go:generate go run github.com/mdigger/router/cmd/routergen -in routes.json -out routes.go
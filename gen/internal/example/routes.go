@@ -0,0 +1,1078 @@
+// Code generated by routergen from routes.json. DO NOT EDIT.
+
+package example
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/mdigger/router"
+)
+
+// Route identifies the route.
+type Route int
+
+// The routes in order of declaration.
+const (
+	RouteNone                             Route = iota // no route found
+	RouteHome                                          // /
+	RouteAuthorizations                                // /authorizations
+	RouteAuthorizationsByID                            // /authorizations/:id
+	RouteApplicationsTokens                            // /applications/:client_id/tokens/:access_token
+	RouteEvents                                        // /events
+	RouteReposEvents                                   // /repos/:owner/:repo/events
+	RouteNetworksEvents                                // /networks/:owner/:repo/events
+	RouteOrgsEvents                                    // /orgs/:org/events
+	RouteUsersReceivedEvents                           // /users/:user/received_events
+	RouteUsersReceivedEventsPublic                     // /users/:user/received_events/public
+	RouteUsersEvents                                   // /users/:user/events
+	RouteUsersEventsPublic                             // /users/:user/events/public
+	RouteUsersEventsOrgs                               // /users/:user/events/orgs/:org
+	RouteFeeds                                         // /feeds
+	RouteNotifications                                 // /notifications
+	RouteReposNotifications                            // /repos/:owner/:repo/notifications
+	RouteNotificationsThreads                          // /notifications/threads/:id
+	RouteNotificationsThreadsSubscription              // /notifications/threads/:id/subscription
+	RouteReposStargazers                               // /repos/:owner/:repo/stargazers
+	RouteUsersStarred                                  // /users/:user/starred
+	RouteUserStarred                                   // /user/starred
+	RouteUserStarredByOwnerRepo                        // /user/starred/:owner/:repo
+	RouteGists                                         // /gists
+	RouteGistsByID                                     // /gists/:id
+	RouteGistsStar                                     // /gists/:id/star
+	RouteReposGitBlobs                                 // /repos/:owner/:repo/git/blobs/:sha
+	RouteReposPulls                                    // /repos/:owner/:repo/pulls
+	RouteReposPullsByOwnerRepoNumber                   // /repos/:owner/:repo/pulls/:number
+	RouteReposPullsFiles                               // /repos/:owner/:repo/pulls/:number/files
+	RouteRepos                                         // /repos/:owner/:repo
+	RouteReposContents                                 // /repos/:owner/:repo/contents/*path
+	RouteReposByOwnerRepoSection                       // /repos/:owner/:repo/:section
+	RouteUser                                          // /user
+	RouteUsers                                         // /users
+	RouteUsersByUser                                   // /users/:user
+	RouteUsers2                                        // /users/
+	RouteMxproxy                                       // /:name/mxproxy
+	RouteStore                                         // /:name/store/*filename
+	RouteStatic                                        // /static/*filepath
+	RouteLegacyIssuesSearch                            // /legacy/issues/search/:owner/:repository/:state/:keyword
+	RouteRoot                                          // /*fallback
+)
+
+// routePatterns contains the patterns of routes.
+var routePatterns = [...]string{
+	RouteNone:                             "",
+	RouteHome:                             "/",
+	RouteAuthorizations:                   "/authorizations",
+	RouteAuthorizationsByID:               "/authorizations/:id",
+	RouteApplicationsTokens:               "/applications/:client_id/tokens/:access_token",
+	RouteEvents:                           "/events",
+	RouteReposEvents:                      "/repos/:owner/:repo/events",
+	RouteNetworksEvents:                   "/networks/:owner/:repo/events",
+	RouteOrgsEvents:                       "/orgs/:org/events",
+	RouteUsersReceivedEvents:              "/users/:user/received_events",
+	RouteUsersReceivedEventsPublic:        "/users/:user/received_events/public",
+	RouteUsersEvents:                      "/users/:user/events",
+	RouteUsersEventsPublic:                "/users/:user/events/public",
+	RouteUsersEventsOrgs:                  "/users/:user/events/orgs/:org",
+	RouteFeeds:                            "/feeds",
+	RouteNotifications:                    "/notifications",
+	RouteReposNotifications:               "/repos/:owner/:repo/notifications",
+	RouteNotificationsThreads:             "/notifications/threads/:id",
+	RouteNotificationsThreadsSubscription: "/notifications/threads/:id/subscription",
+	RouteReposStargazers:                  "/repos/:owner/:repo/stargazers",
+	RouteUsersStarred:                     "/users/:user/starred",
+	RouteUserStarred:                      "/user/starred",
+	RouteUserStarredByOwnerRepo:           "/user/starred/:owner/:repo",
+	RouteGists:                            "/gists",
+	RouteGistsByID:                        "/gists/:id",
+	RouteGistsStar:                        "/gists/:id/star",
+	RouteReposGitBlobs:                    "/repos/:owner/:repo/git/blobs/:sha",
+	RouteReposPulls:                       "/repos/:owner/:repo/pulls",
+	RouteReposPullsByOwnerRepoNumber:      "/repos/:owner/:repo/pulls/:number",
+	RouteReposPullsFiles:                  "/repos/:owner/:repo/pulls/:number/files",
+	RouteRepos:                            "/repos/:owner/:repo",
+	RouteReposContents:                    "/repos/:owner/:repo/contents/*path",
+	RouteReposByOwnerRepoSection:          "/repos/:owner/:repo/:section",
+	RouteUser:                             "/user",
+	RouteUsers:                            "/users",
+	RouteUsersByUser:                      "/users/:user",
+	RouteUsers2:                           "/users/",
+	RouteMxproxy:                          "/:name/mxproxy",
+	RouteStore:                            "/:name/store/*filename",
+	RouteStatic:                           "/static/*filepath",
+	RouteLegacyIssuesSearch:               "/legacy/issues/search/:owner/:repository/:state/:keyword",
+	RouteRoot:                             "/*fallback",
+}
+
+// Pattern returns the pattern of the route.
+func (r Route) Pattern() string {
+	if r < 0 || int(r) >= len(routePatterns) {
+		return ""
+	}
+	return routePatterns[r]
+}
+
+// Lookup returns the route for the path and the values of its named
+// parameters. If no route is found, it returns RouteNone.
+func Lookup(path string) (Route, router.Params) {
+	return lookup(path)
+}
+
+// lookup selects the route for the path.
+func lookup(path string) (Route, router.Params) {
+	path = strings.TrimPrefix(path, "/")
+	switch path {
+	case "":
+		return RouteHome, nil
+	case "authorizations":
+		return RouteAuthorizations, nil
+	case "events":
+		return RouteEvents, nil
+	case "feeds":
+		return RouteFeeds, nil
+	case "notifications":
+		return RouteNotifications, nil
+	case "user/starred":
+		return RouteUserStarred, nil
+	case "gists":
+		return RouteGists, nil
+	case "user":
+		return RouteUser, nil
+	case "users":
+		return RouteUsers, nil
+	case "users/":
+		return RouteUsers2, nil
+	}
+	parts := strings.Split(path, "/")
+	n := len(parts)
+	if n == 7 {
+		// /legacy/issues/search/:owner/:repository/:state/:keyword
+		if parts[0] == "legacy" && parts[1] == "issues" && parts[2] == "search" {
+			return RouteLegacyIssuesSearch, router.Params{{Key: "owner", Value: parts[3]}, {Key: "repository", Value: parts[4]}, {Key: "state", Value: parts[5]}, {Key: "keyword", Value: parts[6]}}
+		}
+	}
+	if n == 6 {
+		// /repos/:owner/:repo/git/blobs/:sha
+		if parts[0] == "repos" && parts[3] == "git" && parts[4] == "blobs" {
+			return RouteReposGitBlobs, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}, {Key: "sha", Value: parts[5]}}
+		}
+		// /repos/:owner/:repo/pulls/:number/files
+		if parts[0] == "repos" && parts[3] == "pulls" && parts[5] == "files" {
+			return RouteReposPullsFiles, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}, {Key: "number", Value: parts[4]}}
+		}
+	}
+	if n == 5 {
+		// /users/:user/events/orgs/:org
+		if parts[0] == "users" && parts[2] == "events" && parts[3] == "orgs" {
+			return RouteUsersEventsOrgs, router.Params{{Key: "user", Value: parts[1]}, {Key: "org", Value: parts[4]}}
+		}
+		// /repos/:owner/:repo/pulls/:number
+		if parts[0] == "repos" && parts[3] == "pulls" {
+			return RouteReposPullsByOwnerRepoNumber, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}, {Key: "number", Value: parts[4]}}
+		}
+	}
+	if n >= 5 {
+		// /repos/:owner/:repo/contents/*path
+		if parts[0] == "repos" && parts[3] == "contents" {
+			return RouteReposContents, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}, {Key: "path", Value: strings.Join(parts[4:], "/")}}
+		}
+	}
+	if n == 4 {
+		// /notifications/threads/:id/subscription
+		if parts[0] == "notifications" && parts[1] == "threads" && parts[3] == "subscription" {
+			return RouteNotificationsThreadsSubscription, router.Params{{Key: "id", Value: parts[2]}}
+		}
+		// /users/:user/received_events/public
+		if parts[0] == "users" && parts[2] == "received_events" && parts[3] == "public" {
+			return RouteUsersReceivedEventsPublic, router.Params{{Key: "user", Value: parts[1]}}
+		}
+		// /users/:user/events/public
+		if parts[0] == "users" && parts[2] == "events" && parts[3] == "public" {
+			return RouteUsersEventsPublic, router.Params{{Key: "user", Value: parts[1]}}
+		}
+		// /user/starred/:owner/:repo
+		if parts[0] == "user" && parts[1] == "starred" {
+			return RouteUserStarredByOwnerRepo, router.Params{{Key: "owner", Value: parts[2]}, {Key: "repo", Value: parts[3]}}
+		}
+		// /applications/:client_id/tokens/:access_token
+		if parts[0] == "applications" && parts[2] == "tokens" {
+			return RouteApplicationsTokens, router.Params{{Key: "client_id", Value: parts[1]}, {Key: "access_token", Value: parts[3]}}
+		}
+		// /repos/:owner/:repo/events
+		if parts[0] == "repos" && parts[3] == "events" {
+			return RouteReposEvents, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}}
+		}
+		// /networks/:owner/:repo/events
+		if parts[0] == "networks" && parts[3] == "events" {
+			return RouteNetworksEvents, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}}
+		}
+		// /repos/:owner/:repo/notifications
+		if parts[0] == "repos" && parts[3] == "notifications" {
+			return RouteReposNotifications, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}}
+		}
+		// /repos/:owner/:repo/stargazers
+		if parts[0] == "repos" && parts[3] == "stargazers" {
+			return RouteReposStargazers, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}}
+		}
+		// /repos/:owner/:repo/pulls
+		if parts[0] == "repos" && parts[3] == "pulls" {
+			return RouteReposPulls, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}}
+		}
+		// /repos/:owner/:repo/:section
+		if parts[0] == "repos" {
+			return RouteReposByOwnerRepoSection, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}, {Key: "section", Value: parts[3]}}
+		}
+	}
+	if n == 3 {
+		// /notifications/threads/:id
+		if parts[0] == "notifications" && parts[1] == "threads" {
+			return RouteNotificationsThreads, router.Params{{Key: "id", Value: parts[2]}}
+		}
+		// /orgs/:org/events
+		if parts[0] == "orgs" && parts[2] == "events" {
+			return RouteOrgsEvents, router.Params{{Key: "org", Value: parts[1]}}
+		}
+		// /users/:user/received_events
+		if parts[0] == "users" && parts[2] == "received_events" {
+			return RouteUsersReceivedEvents, router.Params{{Key: "user", Value: parts[1]}}
+		}
+		// /users/:user/events
+		if parts[0] == "users" && parts[2] == "events" {
+			return RouteUsersEvents, router.Params{{Key: "user", Value: parts[1]}}
+		}
+		// /users/:user/starred
+		if parts[0] == "users" && parts[2] == "starred" {
+			return RouteUsersStarred, router.Params{{Key: "user", Value: parts[1]}}
+		}
+		// /gists/:id/star
+		if parts[0] == "gists" && parts[2] == "star" {
+			return RouteGistsStar, router.Params{{Key: "id", Value: parts[1]}}
+		}
+		// /repos/:owner/:repo
+		if parts[0] == "repos" {
+			return RouteRepos, router.Params{{Key: "owner", Value: parts[1]}, {Key: "repo", Value: parts[2]}}
+		}
+	}
+	if n >= 3 {
+		// /:name/store/*filename
+		if parts[1] == "store" {
+			return RouteStore, router.Params{{Key: "name", Value: parts[0]}, {Key: "filename", Value: strings.Join(parts[2:], "/")}}
+		}
+	}
+	if n == 2 {
+		// /authorizations/:id
+		if parts[0] == "authorizations" {
+			return RouteAuthorizationsByID, router.Params{{Key: "id", Value: parts[1]}}
+		}
+		// /gists/:id
+		if parts[0] == "gists" {
+			return RouteGistsByID, router.Params{{Key: "id", Value: parts[1]}}
+		}
+		// /users/:user
+		if parts[0] == "users" {
+			return RouteUsersByUser, router.Params{{Key: "user", Value: parts[1]}}
+		}
+		// /:name/mxproxy
+		if parts[1] == "mxproxy" {
+			return RouteMxproxy, router.Params{{Key: "name", Value: parts[0]}}
+		}
+	}
+	if n >= 2 {
+		// /static/*filepath
+		if parts[0] == "static" {
+			return RouteStatic, router.Params{{Key: "filepath", Value: strings.Join(parts[1:], "/")}}
+		}
+	}
+	if n >= 1 {
+		// /*fallback
+		return RouteRoot, router.Params{{Key: "fallback", Value: strings.Join(parts[0:], "/")}}
+	}
+	return RouteNone, nil
+}
+
+// AuthorizationsByIDParams contains the parameters of the route /authorizations/:id.
+type AuthorizationsByIDParams struct {
+	ID string // id
+}
+
+// NewAuthorizationsByIDParams returns the parameters of the route /authorizations/:id.
+func NewAuthorizationsByIDParams(params router.Params) AuthorizationsByIDParams {
+	return AuthorizationsByIDParams{
+		ID: params.Get("id"),
+	}
+}
+
+// URL returns the path of the route /authorizations/:id with escaped parameters.
+func (p AuthorizationsByIDParams) URL() string {
+	return AuthorizationsByIDURL(p.ID)
+}
+
+// ApplicationsTokensParams contains the parameters of the route /applications/:client_id/tokens/:access_token.
+type ApplicationsTokensParams struct {
+	ClientID    string // client_id
+	AccessToken string // access_token
+}
+
+// NewApplicationsTokensParams returns the parameters of the route /applications/:client_id/tokens/:access_token.
+func NewApplicationsTokensParams(params router.Params) ApplicationsTokensParams {
+	return ApplicationsTokensParams{
+		ClientID:    params.Get("client_id"),
+		AccessToken: params.Get("access_token"),
+	}
+}
+
+// URL returns the path of the route /applications/:client_id/tokens/:access_token with escaped parameters.
+func (p ApplicationsTokensParams) URL() string {
+	return ApplicationsTokensURL(p.ClientID, p.AccessToken)
+}
+
+// ReposEventsParams contains the parameters of the route /repos/:owner/:repo/events.
+type ReposEventsParams struct {
+	Owner string // owner
+	Repo  string // repo
+}
+
+// NewReposEventsParams returns the parameters of the route /repos/:owner/:repo/events.
+func NewReposEventsParams(params router.Params) ReposEventsParams {
+	return ReposEventsParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/events with escaped parameters.
+func (p ReposEventsParams) URL() string {
+	return ReposEventsURL(p.Owner, p.Repo)
+}
+
+// NetworksEventsParams contains the parameters of the route /networks/:owner/:repo/events.
+type NetworksEventsParams struct {
+	Owner string // owner
+	Repo  string // repo
+}
+
+// NewNetworksEventsParams returns the parameters of the route /networks/:owner/:repo/events.
+func NewNetworksEventsParams(params router.Params) NetworksEventsParams {
+	return NetworksEventsParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+	}
+}
+
+// URL returns the path of the route /networks/:owner/:repo/events with escaped parameters.
+func (p NetworksEventsParams) URL() string {
+	return NetworksEventsURL(p.Owner, p.Repo)
+}
+
+// OrgsEventsParams contains the parameters of the route /orgs/:org/events.
+type OrgsEventsParams struct {
+	Org string // org
+}
+
+// NewOrgsEventsParams returns the parameters of the route /orgs/:org/events.
+func NewOrgsEventsParams(params router.Params) OrgsEventsParams {
+	return OrgsEventsParams{
+		Org: params.Get("org"),
+	}
+}
+
+// URL returns the path of the route /orgs/:org/events with escaped parameters.
+func (p OrgsEventsParams) URL() string {
+	return OrgsEventsURL(p.Org)
+}
+
+// UsersReceivedEventsParams contains the parameters of the route /users/:user/received_events.
+type UsersReceivedEventsParams struct {
+	User string // user
+}
+
+// NewUsersReceivedEventsParams returns the parameters of the route /users/:user/received_events.
+func NewUsersReceivedEventsParams(params router.Params) UsersReceivedEventsParams {
+	return UsersReceivedEventsParams{
+		User: params.Get("user"),
+	}
+}
+
+// URL returns the path of the route /users/:user/received_events with escaped parameters.
+func (p UsersReceivedEventsParams) URL() string {
+	return UsersReceivedEventsURL(p.User)
+}
+
+// UsersReceivedEventsPublicParams contains the parameters of the route /users/:user/received_events/public.
+type UsersReceivedEventsPublicParams struct {
+	User string // user
+}
+
+// NewUsersReceivedEventsPublicParams returns the parameters of the route /users/:user/received_events/public.
+func NewUsersReceivedEventsPublicParams(params router.Params) UsersReceivedEventsPublicParams {
+	return UsersReceivedEventsPublicParams{
+		User: params.Get("user"),
+	}
+}
+
+// URL returns the path of the route /users/:user/received_events/public with escaped parameters.
+func (p UsersReceivedEventsPublicParams) URL() string {
+	return UsersReceivedEventsPublicURL(p.User)
+}
+
+// UsersEventsParams contains the parameters of the route /users/:user/events.
+type UsersEventsParams struct {
+	User string // user
+}
+
+// NewUsersEventsParams returns the parameters of the route /users/:user/events.
+func NewUsersEventsParams(params router.Params) UsersEventsParams {
+	return UsersEventsParams{
+		User: params.Get("user"),
+	}
+}
+
+// URL returns the path of the route /users/:user/events with escaped parameters.
+func (p UsersEventsParams) URL() string {
+	return UsersEventsURL(p.User)
+}
+
+// UsersEventsPublicParams contains the parameters of the route /users/:user/events/public.
+type UsersEventsPublicParams struct {
+	User string // user
+}
+
+// NewUsersEventsPublicParams returns the parameters of the route /users/:user/events/public.
+func NewUsersEventsPublicParams(params router.Params) UsersEventsPublicParams {
+	return UsersEventsPublicParams{
+		User: params.Get("user"),
+	}
+}
+
+// URL returns the path of the route /users/:user/events/public with escaped parameters.
+func (p UsersEventsPublicParams) URL() string {
+	return UsersEventsPublicURL(p.User)
+}
+
+// UsersEventsOrgsParams contains the parameters of the route /users/:user/events/orgs/:org.
+type UsersEventsOrgsParams struct {
+	User string // user
+	Org  string // org
+}
+
+// NewUsersEventsOrgsParams returns the parameters of the route /users/:user/events/orgs/:org.
+func NewUsersEventsOrgsParams(params router.Params) UsersEventsOrgsParams {
+	return UsersEventsOrgsParams{
+		User: params.Get("user"),
+		Org:  params.Get("org"),
+	}
+}
+
+// URL returns the path of the route /users/:user/events/orgs/:org with escaped parameters.
+func (p UsersEventsOrgsParams) URL() string {
+	return UsersEventsOrgsURL(p.User, p.Org)
+}
+
+// ReposNotificationsParams contains the parameters of the route /repos/:owner/:repo/notifications.
+type ReposNotificationsParams struct {
+	Owner string // owner
+	Repo  string // repo
+}
+
+// NewReposNotificationsParams returns the parameters of the route /repos/:owner/:repo/notifications.
+func NewReposNotificationsParams(params router.Params) ReposNotificationsParams {
+	return ReposNotificationsParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/notifications with escaped parameters.
+func (p ReposNotificationsParams) URL() string {
+	return ReposNotificationsURL(p.Owner, p.Repo)
+}
+
+// NotificationsThreadsParams contains the parameters of the route /notifications/threads/:id.
+type NotificationsThreadsParams struct {
+	ID string // id
+}
+
+// NewNotificationsThreadsParams returns the parameters of the route /notifications/threads/:id.
+func NewNotificationsThreadsParams(params router.Params) NotificationsThreadsParams {
+	return NotificationsThreadsParams{
+		ID: params.Get("id"),
+	}
+}
+
+// URL returns the path of the route /notifications/threads/:id with escaped parameters.
+func (p NotificationsThreadsParams) URL() string {
+	return NotificationsThreadsURL(p.ID)
+}
+
+// NotificationsThreadsSubscriptionParams contains the parameters of the route /notifications/threads/:id/subscription.
+type NotificationsThreadsSubscriptionParams struct {
+	ID string // id
+}
+
+// NewNotificationsThreadsSubscriptionParams returns the parameters of the route /notifications/threads/:id/subscription.
+func NewNotificationsThreadsSubscriptionParams(params router.Params) NotificationsThreadsSubscriptionParams {
+	return NotificationsThreadsSubscriptionParams{
+		ID: params.Get("id"),
+	}
+}
+
+// URL returns the path of the route /notifications/threads/:id/subscription with escaped parameters.
+func (p NotificationsThreadsSubscriptionParams) URL() string {
+	return NotificationsThreadsSubscriptionURL(p.ID)
+}
+
+// ReposStargazersParams contains the parameters of the route /repos/:owner/:repo/stargazers.
+type ReposStargazersParams struct {
+	Owner string // owner
+	Repo  string // repo
+}
+
+// NewReposStargazersParams returns the parameters of the route /repos/:owner/:repo/stargazers.
+func NewReposStargazersParams(params router.Params) ReposStargazersParams {
+	return ReposStargazersParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/stargazers with escaped parameters.
+func (p ReposStargazersParams) URL() string {
+	return ReposStargazersURL(p.Owner, p.Repo)
+}
+
+// UsersStarredParams contains the parameters of the route /users/:user/starred.
+type UsersStarredParams struct {
+	User string // user
+}
+
+// NewUsersStarredParams returns the parameters of the route /users/:user/starred.
+func NewUsersStarredParams(params router.Params) UsersStarredParams {
+	return UsersStarredParams{
+		User: params.Get("user"),
+	}
+}
+
+// URL returns the path of the route /users/:user/starred with escaped parameters.
+func (p UsersStarredParams) URL() string {
+	return UsersStarredURL(p.User)
+}
+
+// UserStarredByOwnerRepoParams contains the parameters of the route /user/starred/:owner/:repo.
+type UserStarredByOwnerRepoParams struct {
+	Owner string // owner
+	Repo  string // repo
+}
+
+// NewUserStarredByOwnerRepoParams returns the parameters of the route /user/starred/:owner/:repo.
+func NewUserStarredByOwnerRepoParams(params router.Params) UserStarredByOwnerRepoParams {
+	return UserStarredByOwnerRepoParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+	}
+}
+
+// URL returns the path of the route /user/starred/:owner/:repo with escaped parameters.
+func (p UserStarredByOwnerRepoParams) URL() string {
+	return UserStarredByOwnerRepoURL(p.Owner, p.Repo)
+}
+
+// GistsByIDParams contains the parameters of the route /gists/:id.
+type GistsByIDParams struct {
+	ID string // id
+}
+
+// NewGistsByIDParams returns the parameters of the route /gists/:id.
+func NewGistsByIDParams(params router.Params) GistsByIDParams {
+	return GistsByIDParams{
+		ID: params.Get("id"),
+	}
+}
+
+// URL returns the path of the route /gists/:id with escaped parameters.
+func (p GistsByIDParams) URL() string {
+	return GistsByIDURL(p.ID)
+}
+
+// GistsStarParams contains the parameters of the route /gists/:id/star.
+type GistsStarParams struct {
+	ID string // id
+}
+
+// NewGistsStarParams returns the parameters of the route /gists/:id/star.
+func NewGistsStarParams(params router.Params) GistsStarParams {
+	return GistsStarParams{
+		ID: params.Get("id"),
+	}
+}
+
+// URL returns the path of the route /gists/:id/star with escaped parameters.
+func (p GistsStarParams) URL() string {
+	return GistsStarURL(p.ID)
+}
+
+// ReposGitBlobsParams contains the parameters of the route /repos/:owner/:repo/git/blobs/:sha.
+type ReposGitBlobsParams struct {
+	Owner string // owner
+	Repo  string // repo
+	SHA   string // sha
+}
+
+// NewReposGitBlobsParams returns the parameters of the route /repos/:owner/:repo/git/blobs/:sha.
+func NewReposGitBlobsParams(params router.Params) ReposGitBlobsParams {
+	return ReposGitBlobsParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+		SHA:   params.Get("sha"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/git/blobs/:sha with escaped parameters.
+func (p ReposGitBlobsParams) URL() string {
+	return ReposGitBlobsURL(p.Owner, p.Repo, p.SHA)
+}
+
+// ReposPullsParams contains the parameters of the route /repos/:owner/:repo/pulls.
+type ReposPullsParams struct {
+	Owner string // owner
+	Repo  string // repo
+}
+
+// NewReposPullsParams returns the parameters of the route /repos/:owner/:repo/pulls.
+func NewReposPullsParams(params router.Params) ReposPullsParams {
+	return ReposPullsParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/pulls with escaped parameters.
+func (p ReposPullsParams) URL() string {
+	return ReposPullsURL(p.Owner, p.Repo)
+}
+
+// ReposPullsByOwnerRepoNumberParams contains the parameters of the route /repos/:owner/:repo/pulls/:number.
+type ReposPullsByOwnerRepoNumberParams struct {
+	Owner  string // owner
+	Repo   string // repo
+	Number string // number
+}
+
+// NewReposPullsByOwnerRepoNumberParams returns the parameters of the route /repos/:owner/:repo/pulls/:number.
+func NewReposPullsByOwnerRepoNumberParams(params router.Params) ReposPullsByOwnerRepoNumberParams {
+	return ReposPullsByOwnerRepoNumberParams{
+		Owner:  params.Get("owner"),
+		Repo:   params.Get("repo"),
+		Number: params.Get("number"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/pulls/:number with escaped parameters.
+func (p ReposPullsByOwnerRepoNumberParams) URL() string {
+	return ReposPullsByOwnerRepoNumberURL(p.Owner, p.Repo, p.Number)
+}
+
+// ReposPullsFilesParams contains the parameters of the route /repos/:owner/:repo/pulls/:number/files.
+type ReposPullsFilesParams struct {
+	Owner  string // owner
+	Repo   string // repo
+	Number string // number
+}
+
+// NewReposPullsFilesParams returns the parameters of the route /repos/:owner/:repo/pulls/:number/files.
+func NewReposPullsFilesParams(params router.Params) ReposPullsFilesParams {
+	return ReposPullsFilesParams{
+		Owner:  params.Get("owner"),
+		Repo:   params.Get("repo"),
+		Number: params.Get("number"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/pulls/:number/files with escaped parameters.
+func (p ReposPullsFilesParams) URL() string {
+	return ReposPullsFilesURL(p.Owner, p.Repo, p.Number)
+}
+
+// ReposParams contains the parameters of the route /repos/:owner/:repo.
+type ReposParams struct {
+	Owner string // owner
+	Repo  string // repo
+}
+
+// NewReposParams returns the parameters of the route /repos/:owner/:repo.
+func NewReposParams(params router.Params) ReposParams {
+	return ReposParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo with escaped parameters.
+func (p ReposParams) URL() string {
+	return ReposURL(p.Owner, p.Repo)
+}
+
+// ReposContentsParams contains the parameters of the route /repos/:owner/:repo/contents/*path.
+type ReposContentsParams struct {
+	Owner string // owner
+	Repo  string // repo
+	Path  string // path
+}
+
+// NewReposContentsParams returns the parameters of the route /repos/:owner/:repo/contents/*path.
+func NewReposContentsParams(params router.Params) ReposContentsParams {
+	return ReposContentsParams{
+		Owner: params.Get("owner"),
+		Repo:  params.Get("repo"),
+		Path:  params.Get("path"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/contents/*path with escaped parameters.
+func (p ReposContentsParams) URL() string {
+	return ReposContentsURL(p.Owner, p.Repo, p.Path)
+}
+
+// ReposByOwnerRepoSectionParams contains the parameters of the route /repos/:owner/:repo/:section.
+type ReposByOwnerRepoSectionParams struct {
+	Owner   string // owner
+	Repo    string // repo
+	Section string // section
+}
+
+// NewReposByOwnerRepoSectionParams returns the parameters of the route /repos/:owner/:repo/:section.
+func NewReposByOwnerRepoSectionParams(params router.Params) ReposByOwnerRepoSectionParams {
+	return ReposByOwnerRepoSectionParams{
+		Owner:   params.Get("owner"),
+		Repo:    params.Get("repo"),
+		Section: params.Get("section"),
+	}
+}
+
+// URL returns the path of the route /repos/:owner/:repo/:section with escaped parameters.
+func (p ReposByOwnerRepoSectionParams) URL() string {
+	return ReposByOwnerRepoSectionURL(p.Owner, p.Repo, p.Section)
+}
+
+// UsersByUserParams contains the parameters of the route /users/:user.
+type UsersByUserParams struct {
+	User string // user
+}
+
+// NewUsersByUserParams returns the parameters of the route /users/:user.
+func NewUsersByUserParams(params router.Params) UsersByUserParams {
+	return UsersByUserParams{
+		User: params.Get("user"),
+	}
+}
+
+// URL returns the path of the route /users/:user with escaped parameters.
+func (p UsersByUserParams) URL() string {
+	return UsersByUserURL(p.User)
+}
+
+// MxproxyParams contains the parameters of the route /:name/mxproxy.
+type MxproxyParams struct {
+	Name string // name
+}
+
+// NewMxproxyParams returns the parameters of the route /:name/mxproxy.
+func NewMxproxyParams(params router.Params) MxproxyParams {
+	return MxproxyParams{
+		Name: params.Get("name"),
+	}
+}
+
+// URL returns the path of the route /:name/mxproxy with escaped parameters.
+func (p MxproxyParams) URL() string {
+	return MxproxyURL(p.Name)
+}
+
+// StoreParams contains the parameters of the route /:name/store/*filename.
+type StoreParams struct {
+	Name     string // name
+	Filename string // filename
+}
+
+// NewStoreParams returns the parameters of the route /:name/store/*filename.
+func NewStoreParams(params router.Params) StoreParams {
+	return StoreParams{
+		Name:     params.Get("name"),
+		Filename: params.Get("filename"),
+	}
+}
+
+// URL returns the path of the route /:name/store/*filename with escaped parameters.
+func (p StoreParams) URL() string {
+	return StoreURL(p.Name, p.Filename)
+}
+
+// StaticParams contains the parameters of the route /static/*filepath.
+type StaticParams struct {
+	Filepath string // filepath
+}
+
+// NewStaticParams returns the parameters of the route /static/*filepath.
+func NewStaticParams(params router.Params) StaticParams {
+	return StaticParams{
+		Filepath: params.Get("filepath"),
+	}
+}
+
+// URL returns the path of the route /static/*filepath with escaped parameters.
+func (p StaticParams) URL() string {
+	return StaticURL(p.Filepath)
+}
+
+// LegacyIssuesSearchParams contains the parameters of the route /legacy/issues/search/:owner/:repository/:state/:keyword.
+type LegacyIssuesSearchParams struct {
+	Owner      string // owner
+	Repository string // repository
+	State      string // state
+	Keyword    string // keyword
+}
+
+// NewLegacyIssuesSearchParams returns the parameters of the route /legacy/issues/search/:owner/:repository/:state/:keyword.
+func NewLegacyIssuesSearchParams(params router.Params) LegacyIssuesSearchParams {
+	return LegacyIssuesSearchParams{
+		Owner:      params.Get("owner"),
+		Repository: params.Get("repository"),
+		State:      params.Get("state"),
+		Keyword:    params.Get("keyword"),
+	}
+}
+
+// URL returns the path of the route /legacy/issues/search/:owner/:repository/:state/:keyword with escaped parameters.
+func (p LegacyIssuesSearchParams) URL() string {
+	return LegacyIssuesSearchURL(p.Owner, p.Repository, p.State, p.Keyword)
+}
+
+// RootParams contains the parameters of the route /*fallback.
+type RootParams struct {
+	Fallback string // fallback
+}
+
+// NewRootParams returns the parameters of the route /*fallback.
+func NewRootParams(params router.Params) RootParams {
+	return RootParams{
+		Fallback: params.Get("fallback"),
+	}
+}
+
+// URL returns the path of the route /*fallback with escaped parameters.
+func (p RootParams) URL() string {
+	return RootURL(p.Fallback)
+}
+
+// HomeURL returns the path of the route /.
+func HomeURL() string {
+	return "/"
+}
+
+// AuthorizationsURL returns the path of the route /authorizations.
+func AuthorizationsURL() string {
+	return "/authorizations"
+}
+
+// AuthorizationsByIDURL returns the path of the route /authorizations/:id with escaped parameters.
+func AuthorizationsByIDURL(id string) string {
+	return "/authorizations/" + url.PathEscape(id)
+}
+
+// ApplicationsTokensURL returns the path of the route /applications/:client_id/tokens/:access_token with escaped parameters.
+func ApplicationsTokensURL(clientID, accessToken string) string {
+	return "/applications/" + url.PathEscape(clientID) + "/tokens/" + url.PathEscape(accessToken)
+}
+
+// EventsURL returns the path of the route /events.
+func EventsURL() string {
+	return "/events"
+}
+
+// ReposEventsURL returns the path of the route /repos/:owner/:repo/events with escaped parameters.
+func ReposEventsURL(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/events"
+}
+
+// NetworksEventsURL returns the path of the route /networks/:owner/:repo/events with escaped parameters.
+func NetworksEventsURL(owner, repo string) string {
+	return "/networks/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/events"
+}
+
+// OrgsEventsURL returns the path of the route /orgs/:org/events with escaped parameters.
+func OrgsEventsURL(org string) string {
+	return "/orgs/" + url.PathEscape(org) + "/events"
+}
+
+// UsersReceivedEventsURL returns the path of the route /users/:user/received_events with escaped parameters.
+func UsersReceivedEventsURL(user string) string {
+	return "/users/" + url.PathEscape(user) + "/received_events"
+}
+
+// UsersReceivedEventsPublicURL returns the path of the route /users/:user/received_events/public with escaped parameters.
+func UsersReceivedEventsPublicURL(user string) string {
+	return "/users/" + url.PathEscape(user) + "/received_events/public"
+}
+
+// UsersEventsURL returns the path of the route /users/:user/events with escaped parameters.
+func UsersEventsURL(user string) string {
+	return "/users/" + url.PathEscape(user) + "/events"
+}
+
+// UsersEventsPublicURL returns the path of the route /users/:user/events/public with escaped parameters.
+func UsersEventsPublicURL(user string) string {
+	return "/users/" + url.PathEscape(user) + "/events/public"
+}
+
+// UsersEventsOrgsURL returns the path of the route /users/:user/events/orgs/:org with escaped parameters.
+func UsersEventsOrgsURL(user, org string) string {
+	return "/users/" + url.PathEscape(user) + "/events/orgs/" + url.PathEscape(org)
+}
+
+// FeedsURL returns the path of the route /feeds.
+func FeedsURL() string {
+	return "/feeds"
+}
+
+// NotificationsURL returns the path of the route /notifications.
+func NotificationsURL() string {
+	return "/notifications"
+}
+
+// ReposNotificationsURL returns the path of the route /repos/:owner/:repo/notifications with escaped parameters.
+func ReposNotificationsURL(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/notifications"
+}
+
+// NotificationsThreadsURL returns the path of the route /notifications/threads/:id with escaped parameters.
+func NotificationsThreadsURL(id string) string {
+	return "/notifications/threads/" + url.PathEscape(id)
+}
+
+// NotificationsThreadsSubscriptionURL returns the path of the route /notifications/threads/:id/subscription with escaped parameters.
+func NotificationsThreadsSubscriptionURL(id string) string {
+	return "/notifications/threads/" + url.PathEscape(id) + "/subscription"
+}
+
+// ReposStargazersURL returns the path of the route /repos/:owner/:repo/stargazers with escaped parameters.
+func ReposStargazersURL(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/stargazers"
+}
+
+// UsersStarredURL returns the path of the route /users/:user/starred with escaped parameters.
+func UsersStarredURL(user string) string {
+	return "/users/" + url.PathEscape(user) + "/starred"
+}
+
+// UserStarredURL returns the path of the route /user/starred.
+func UserStarredURL() string {
+	return "/user/starred"
+}
+
+// UserStarredByOwnerRepoURL returns the path of the route /user/starred/:owner/:repo with escaped parameters.
+func UserStarredByOwnerRepoURL(owner, repo string) string {
+	return "/user/starred/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// GistsURL returns the path of the route /gists.
+func GistsURL() string {
+	return "/gists"
+}
+
+// GistsByIDURL returns the path of the route /gists/:id with escaped parameters.
+func GistsByIDURL(id string) string {
+	return "/gists/" + url.PathEscape(id)
+}
+
+// GistsStarURL returns the path of the route /gists/:id/star with escaped parameters.
+func GistsStarURL(id string) string {
+	return "/gists/" + url.PathEscape(id) + "/star"
+}
+
+// ReposGitBlobsURL returns the path of the route /repos/:owner/:repo/git/blobs/:sha with escaped parameters.
+func ReposGitBlobsURL(owner, repo, sha string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/git/blobs/" + url.PathEscape(sha)
+}
+
+// ReposPullsURL returns the path of the route /repos/:owner/:repo/pulls with escaped parameters.
+func ReposPullsURL(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/pulls"
+}
+
+// ReposPullsByOwnerRepoNumberURL returns the path of the route /repos/:owner/:repo/pulls/:number with escaped parameters.
+func ReposPullsByOwnerRepoNumberURL(owner, repo, number string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/pulls/" + url.PathEscape(number)
+}
+
+// ReposPullsFilesURL returns the path of the route /repos/:owner/:repo/pulls/:number/files with escaped parameters.
+func ReposPullsFilesURL(owner, repo, number string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/pulls/" + url.PathEscape(number) + "/files"
+}
+
+// ReposURL returns the path of the route /repos/:owner/:repo with escaped parameters.
+func ReposURL(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// ReposContentsURL returns the path of the route /repos/:owner/:repo/contents/*path with escaped parameters.
+func ReposContentsURL(owner, repo, path string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/contents/" + escapePath(path)
+}
+
+// ReposByOwnerRepoSectionURL returns the path of the route /repos/:owner/:repo/:section with escaped parameters.
+func ReposByOwnerRepoSectionURL(owner, repo, section string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/" + url.PathEscape(section)
+}
+
+// UserURL returns the path of the route /user.
+func UserURL() string {
+	return "/user"
+}
+
+// UsersURL returns the path of the route /users.
+func UsersURL() string {
+	return "/users"
+}
+
+// UsersByUserURL returns the path of the route /users/:user with escaped parameters.
+func UsersByUserURL(user string) string {
+	return "/users/" + url.PathEscape(user)
+}
+
+// Users2URL returns the path of the route /users/.
+func Users2URL() string {
+	return "/users/"
+}
+
+// MxproxyURL returns the path of the route /:name/mxproxy with escaped parameters.
+func MxproxyURL(name string) string {
+	return "/" + url.PathEscape(name) + "/mxproxy"
+}
+
+// StoreURL returns the path of the route /:name/store/*filename with escaped parameters.
+func StoreURL(name, filename string) string {
+	return "/" + url.PathEscape(name) + "/store/" + escapePath(filename)
+}
+
+// StaticURL returns the path of the route /static/*filepath with escaped parameters.
+func StaticURL(filepath string) string {
+	return "/static/" + escapePath(filepath)
+}
+
+// LegacyIssuesSearchURL returns the path of the route /legacy/issues/search/:owner/:repository/:state/:keyword with escaped parameters.
+func LegacyIssuesSearchURL(owner, repository, state, keyword string) string {
+	return "/legacy/issues/search/" + url.PathEscape(owner) + "/" + url.PathEscape(repository) + "/" + url.PathEscape(state) + "/" + url.PathEscape(keyword)
+}
+
+// RootURL returns the path of the route /*fallback with escaped parameters.
+func RootURL(fallback string) string {
+	return "/" + escapePath(fallback)
+}
+
+// escapePath escapes each element of the path.
+func escapePath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,107 @@
+package example
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mdigger/router"
+)
+
+func TestLookup(t *testing.T) {
+	file, err := os.Open("routes.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	entries, err := router.ReadRoutes(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var table router.Table[Route]
+	for i, entry := range entries {
+		if err := table.Add(entry.Pattern, Route(i+1)); err != nil {
+			t.Fatal(err)
+		}
+		if pattern := Route(i + 1).Pattern(); pattern != entry.Pattern {
+			t.Errorf("bad pattern: %v", pattern)
+		}
+	}
+	for _, url := range []string{
+		"/",
+		"",
+		"/authorizations",
+		"/authorizations/1",
+		"/authorizations/1/",
+		"/applications/1/tokens/zohRoo7e",
+		"/applications/1/tokens",
+		"/repos/mdigger/rest/events",
+		"/networks/mdigger/rest/events",
+		"/users/mdigger/received_events/public",
+		"/users/mdigger/events/orgs/something",
+		"/users/mdigger/events/orgs",
+		"/notifications/threads/1/subscription",
+		"/user/starred/mdigger/rest",
+		"/gists/2/star",
+		"/repos/mdigger/rest/git/blobs/d30039aa",
+		"/repos/mdigger/rest/pulls/1/files",
+		"/repos/mdigger/rest/pulls/1",
+		"/repos/mdigger/rest/pulls",
+		"/repos/mdigger/rest",
+		"/repos/mdigger/rest/contents",
+		"/repos/mdigger/rest/contents/",
+		"/repos/mdigger/rest/contents/a/b/c",
+		"/repos/mdigger/rest/issues",
+		"/user",
+		"/users",
+		"/users/",
+		"/users/mdigger",
+		"/xyzrd/mxproxy",
+		"/xyzrd/mxproxy/",
+		"/xyzrd/store",
+		"/xyzrd/store/file/name/",
+		"/static/css/main.css",
+		"/legacy/issues/search/mdigger/rest/closed/test",
+		"/legacy/issues/search/mdigger/rest/closed/test/more",
+		"/missing",
+		"/missing/a/b/c/d/e/f/g/h",
+		"//",
+	} {
+		want, wantParams := table.Lookup(url)
+		route, params := Lookup(url)
+		if route != want || !reflect.DeepEqual(params, wantParams) {
+			t.Errorf("%v: bad route %v %v, want %v %v", url, route.Pattern(), params,
+				want.Pattern(), wantParams)
+		}
+	}
+	if Route(-1).Pattern() != "" || Route(len(entries)+1).Pattern() != "" {
+		t.Error("bad pattern of unknown route")
+	}
+}
+
+func TestParams(t *testing.T) {
+	route, params := Lookup("/repos/mdigger/rest/contents/a b/c%d")
+	if route != RouteReposContents {
+		t.Fatalf("bad route: %v", route.Pattern())
+	}
+	p := NewReposContentsParams(params)
+	if p != (ReposContentsParams{Owner: "mdigger", Repo: "rest", Path: "a b/c%d"}) {
+		t.Errorf("bad params: %+v", p)
+	}
+	if url := p.URL(); url != "/repos/mdigger/rest/contents/a%20b/c%25d" {
+		t.Errorf("bad url: %v", url)
+	}
+	p2 := ApplicationsTokensParams{ClientID: "1/2", AccessToken: "x"}
+	if url := p2.URL(); url != "/applications/1%2F2/tokens/x" {
+		t.Errorf("bad url: %v", url)
+	}
+	for url, want := range map[string]string{
+		HomeURL():                            "/",
+		ReposPullsURL("md", "my repo"):       "/repos/md/my%20repo/pulls",
+		ReposContentsURL("md", "r", "a/b c"): "/repos/md/r/contents/a/b%20c",
+	} {
+		if url != want {
+			t.Errorf("bad url: %v", url)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/mdigger/router"
+)
+
+// ParseGo returns the route declarations found in the Go source file. The
+// declarations are the calls of methods Add of Table or Paths and Handle or
+// HandleFunc of Router with string literals as the method and pattern, for
+// example:
+//
+//	paths.Add("/users/:id", getUser)
+//	r.HandleFunc("GET", "/users/:id", getUser)
+//	r.Handle(http.MethodGet, "/users/:id", getUser)
+//
+// The source text of the handler expression is used as the target name. Calls
+// with other arguments are skipped, as well as prefixes of groups, because
+// they can't be resolved without running the code. The type of the receiver is
+// not checked, so only the patterns starting with a slash are taken to skip
+// calls like Header().Add("Content-Type", "text/plain").
+func ParseGo(filename string, src []byte) ([]router.RouteEntry, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	var entries []router.RouteEntry
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		var entry router.RouteEntry
+		switch selector.Sel.Name {
+		case "Add":
+			if len(call.Args) < 2 {
+				return true
+			}
+			if entry.Pattern, ok = pattern(call.Args[0]); !ok {
+				return true
+			}
+			entry.Target = types.ExprString(call.Args[1])
+		case "Handle", "HandleFunc":
+			if len(call.Args) < 3 {
+				return true
+			}
+			if entry.Method, ok = method(call.Args[0]); !ok {
+				return true
+			}
+			if entry.Pattern, ok = pattern(call.Args[1]); !ok {
+				return true
+			}
+			entry.Target = types.ExprString(call.Args[2])
+		default:
+			return true
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: no route declarations found", filename)
+	}
+	return entries, nil
+}
+
+// literal returns the value of the string literal.
+func literal(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+// pattern returns the pattern of the route from the string literal, if it
+// starts with a slash.
+func pattern(expr ast.Expr) (string, bool) {
+	value, ok := literal(expr)
+	return value, ok && strings.HasPrefix(value, router.PathDelimeter)
+}
+
+// method returns the name of the request method from the string literal or
+// the constant of the net/http package, like http.MethodGet.
+func method(expr ast.Expr) (string, bool) {
+	if selector, ok := expr.(*ast.SelectorExpr); ok {
+		if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == "http" &&
+			strings.HasPrefix(selector.Sel.Name, "Method") {
+			return strings.ToUpper(strings.TrimPrefix(selector.Sel.Name, "Method")), true
+		}
+		return "", false
+	}
+	return literal(expr)
+}
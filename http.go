@@ -0,0 +1,424 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Middleware describes a function that wraps the HTTP handler and allows to
+// add some common behavior before or after the request processing.
+type Middleware func(http.Handler) http.Handler
+
+// Router is an HTTP request router built on top of Table. For each request
+// method used its own set of paths.
+//
+// Middleware can be attached at the router level, at the group level and at
+// the level of an individual route. They are applied in that order: the
+// router middleware is the outermost, then the middleware of the groups from
+// the outer to the inner one and, last, the middleware of the route itself.
+// Within a single level the middleware added first is called first. The
+// router middleware is called also for requests without a suitable route.
+//
+// All middleware is called after the route was selected, so the pattern of
+// the route and the values of the named parameters are already available via
+// GetPattern and GetParams.
+//
+// With the option WithServeMux routes can be registered with the pattern syntax
+// of http.ServeMux, including the method and the host. The routes for a host
+// are checked before the routes without a host. The values of the named
+// parameters are also available via the PathValue method of the request.
+type Router struct {
+	// NotFound is called when no route matches the request path. If not set,
+	// http.NotFound is used.
+	NotFound http.Handler
+	// MethodNotAllowed is called when the request path matches routes only
+	// with other methods. Before the call, the Allow header of the response
+	// already contains the list of these methods. If not set, the router
+	// responds with a 405 status.
+	MethodNotAllowed http.Handler
+
+	methods    map[routeKey]*Table[*Endpoint] // пути, сгруппированные по методам запроса
+	middleware []Middleware                   // обработчики уровня всего роутера
+	count      int                            // количество добавленных путей
+	hosts      bool                           // есть пути для отдельных хостов
+	coverage   *Coverage                      // учет совпадений путей, если включен
+}
+
+// routeKey describes the key of the set of paths of the router.
+type routeKey struct {
+	method string // метод запроса
+	host   string // хост или пустая строка для всех хостов
+}
+
+// Use adds middleware at the router level.
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Handle registers the handler for the specified request method and path.
+// The path and options have the same meaning as in Paths.Add. Returns the
+// registered endpoint, which can be used to add middleware for this route only.
+// Returns an error if the method is empty.
+//
+// If the path is specified with the option WithServeMux and contains a method,
+// the method argument can be empty.
+func (r *Router) Handle(method, url string, handler http.Handler, opts ...Option) (*Endpoint, error) {
+	return r.handle(nil, method, url, handler, opts)
+}
+
+// HandleFunc registers the handler function for the specified request method
+// and path.
+func (r *Router) HandleFunc(method, url string, handler func(http.ResponseWriter, *http.Request), opts ...Option) (*Endpoint, error) {
+	if handler == nil {
+		return nil, errors.New("nil handler")
+	}
+	return r.handle(nil, method, url, http.HandlerFunc(handler), opts)
+}
+
+// Group returns a new group of routes with the specified path prefix.
+func (r *Router) Group(prefix string) *Group {
+	return &Group{router: r, prefix: prefix}
+}
+
+// handle adds a new endpoint to the router.
+func (r *Router) handle(group *Group, method, url string, handler http.Handler, opts []Option) (*Endpoint, error) {
+	// обработчик может быть и типизированным nil, например http.HandlerFunc(nil)
+	if isNil(handler) {
+		return nil, errors.New("nil handler")
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	// путь в синтаксисе http.ServeMux может содержать метод и хост
+	var host string
+	if o.serveMux {
+		var pathMethod string
+		pathMethod, host, url = splitServeMux(url)
+		if pathMethod != "" {
+			if method != "" && method != pathMethod {
+				return nil, fmt.Errorf("method mismatch: %s and %s", method, pathMethod)
+			}
+			method = pathMethod
+		}
+	}
+	// запрос всегда содержит метод, поэтому такой путь никогда не подойдет
+	if method == "" {
+		return nil, errors.New("empty method")
+	}
+	if group != nil {
+		url = group.path(url)
+	}
+	source := host + url // путь в исходном виде для отображения
+	if o.serveMux {
+		var err error
+		if url, err = convertServeMux(url); err != nil {
+			return nil, err
+		}
+	}
+	endpoint := &Endpoint{
+		Method:  method,
+		Host:    host,
+		Pattern: url,
+		Source:  source,
+		handler: handler,
+		group:   group,
+		index:   r.count,
+	}
+	if r.methods == nil {
+		r.methods = make(map[routeKey]*Table[*Endpoint])
+	}
+	key := routeKey{method: method, host: host}
+	paths := r.methods[key]
+	if paths == nil {
+		paths = new(Table[*Endpoint])
+	}
+	if err := paths.add(url, endpoint, o); err != nil {
+		return nil, err
+	}
+	r.methods[key] = paths
+	r.count++
+	if host != "" {
+		r.hosts = true
+	}
+	return endpoint, nil
+}
+
+// Walk calls the function for each registered route in order of adding. If
+// the function returns an error, the walk is stopped and this error is
+// returned. The patterns of routes registered with WithServeMux are converted
+// to the syntax of the router and their host is returned separately.
+func (r *Router) Walk(fn func(Route[http.Handler]) error) error {
+	// собираем пути всех методов и сортируем их в порядке добавления
+	var routes []Route[*Endpoint]
+	for _, paths := range r.methods {
+		routes = append(routes, paths.Routes()...)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Handler.index < routes[j].Handler.index
+	})
+	for _, route := range routes {
+		if err := fn(Route[http.Handler]{
+			Method:  route.Handler.Method,
+			Host:    route.Handler.Host,
+			Pattern: route.Handler.Pattern,
+			Handler: route.Handler.handler,
+			Meta:    route.Meta,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Routes returns the list of all registered routes in order of adding.
+func (r *Router) Routes() []Route[http.Handler] {
+	routes := make([]Route[http.Handler], 0, r.count)
+	r.Walk(func(route Route[http.Handler]) error {
+		routes = append(routes, route)
+		return nil
+	})
+	return routes
+}
+
+// Allowed returns the sorted list of request methods, for which there are
+// routes matching the specified path. The routes for separate hosts are not
+// taken into account.
+func (r *Router) Allowed(url string) []string {
+	return r.allowed("", url)
+}
+
+// Closest returns the route with the specified method, which was rejected for
+// the path last of all, as Table.Closest does. Together with Allowed it allows
+// to tell in the NotFound handler why the path was not matched. The routes for
+// separate hosts are not taken into account.
+func (r *Router) Closest(method, url string) (TraceStep[*Endpoint], bool) {
+	if paths := r.methods[routeKey{method: method}]; paths != nil {
+		return paths.Closest(url)
+	}
+	return TraceStep[*Endpoint]{}, false
+}
+
+// allowed returns the sorted list of request methods, for which there are
+// routes matching the specified host and path.
+func (r *Router) allowed(host, url string) []string {
+	var methods []string
+	for key, paths := range r.methods {
+		if key.host != "" && key.host != host {
+			continue
+		}
+		if endpoint, _ := paths.Lookup(url); endpoint != nil {
+			methods = append(methods, key.method)
+		}
+	}
+	sort.Strings(methods)
+	// метод может встречаться для хоста и для всех хостов сразу
+	for i := len(methods) - 1; i > 0; i-- {
+		if methods[i] == methods[i-1] {
+			methods = append(methods[:i], methods[i+1:]...)
+		}
+	}
+	return methods
+}
+
+// lookup returns the route selected for the request: first among the routes
+// for the host of the request, then among the routes for all hosts.
+func (r *Router) lookup(req *http.Request) (*Match[*Endpoint], bool) {
+	if r.hosts {
+		if paths := r.methods[routeKey{method: req.Method, host: requestHost(req)}]; paths != nil {
+			if match, ok := paths.Match(req.URL.Path); ok {
+				return match, true
+			}
+		}
+	}
+	if paths := r.methods[routeKey{method: req.Method}]; paths != nil {
+		return paths.Match(req.URL.Path)
+	}
+	return nil, false
+}
+
+// requestHost returns the host of the request without the port.
+func requestHost(req *http.Request) string {
+	if host, _, err := net.SplitHostPort(req.Host); err == nil {
+		return host
+	}
+	return req.Host
+}
+
+// ServeHTTP implements the http.Handler interface. It selects the route for
+// the request, saves the information about it in the request context and
+// calls the handler with all its middleware. If no route is found, the
+// NotFound or MethodNotAllowed handler is called.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var (
+		handler http.Handler
+		layers  [][]Middleware
+	)
+	if match, ok := r.lookup(req); ok {
+		endpoint := match.Handler
+		if r.coverage != nil {
+			r.coverage.hit(endpoint.index)
+		}
+		handler = endpoint.handler
+		// собираем обработчики групп от внешней к внутренней
+		for g := endpoint.group; g != nil; g = g.parent {
+			layers = append([][]Middleware{g.middleware}, layers...)
+		}
+		layers = append(layers, endpoint.middleware)
+		// сохраняем информацию о выбранном пути в контексте запроса
+		ctx := context.WithValue(req.Context(), contextKey{},
+			&routeContext{endpoint: endpoint, match: match})
+		req = req.WithContext(ctx)
+		for _, param := range match.Params {
+			req.SetPathValue(param.Key, param.Value)
+		}
+	}
+	if handler == nil {
+		handler = r.notFound(w, req)
+	}
+	layers = append([][]Middleware{r.middleware}, layers...)
+	// оборачиваем обработчик, начиная с самого внутреннего
+	for i := len(layers) - 1; i >= 0; i-- {
+		for j := len(layers[i]) - 1; j >= 0; j-- {
+			handler = layers[i][j](handler)
+		}
+	}
+	handler.ServeHTTP(w, req)
+}
+
+// notFound returns the handler for the request without a suitable route.
+func (r *Router) notFound(w http.ResponseWriter, req *http.Request) http.Handler {
+	// проверяем, не подходит ли путь для других методов
+	if allowed := r.allowed(requestHost(req), req.URL.Path); len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		if r.MethodNotAllowed != nil {
+			return r.MethodNotAllowed
+		}
+		return http.HandlerFunc(methodNotAllowed)
+	}
+	if r.NotFound != nil {
+		return r.NotFound
+	}
+	return http.HandlerFunc(http.NotFound)
+}
+
+// methodNotAllowed replies to the request with an HTTP 405 method not allowed
+// error.
+func methodNotAllowed(w http.ResponseWriter, req *http.Request) {
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed),
+		http.StatusMethodNotAllowed)
+}
+
+// Group describes a group of routes with a common path prefix and middleware.
+type Group struct {
+	router     *Router      // роутер, в котором регистрируются пути
+	parent     *Group       // внешняя группа
+	prefix     string       // префикс пути группы
+	middleware []Middleware // обработчики уровня группы
+}
+
+// Use adds middleware at the group level. It's applied to all routes of the
+// group and of its nested groups, including already registered.
+func (g *Group) Use(middleware ...Middleware) {
+	g.middleware = append(g.middleware, middleware...)
+}
+
+// Handle registers the handler for the specified request method and the path
+// relative to the group prefix.
+func (g *Group) Handle(method, url string, handler http.Handler, opts ...Option) (*Endpoint, error) {
+	return g.router.handle(g, method, url, handler, opts)
+}
+
+// HandleFunc registers the handler function for the specified request method
+// and the path relative to the group prefix.
+func (g *Group) HandleFunc(method, url string, handler func(http.ResponseWriter, *http.Request), opts ...Option) (*Endpoint, error) {
+	if handler == nil {
+		return nil, errors.New("nil handler")
+	}
+	return g.router.handle(g, method, url, http.HandlerFunc(handler), opts)
+}
+
+// Group returns a nested group of routes. Its prefix is added to the prefix
+// of the current group.
+func (g *Group) Group(prefix string) *Group {
+	return &Group{router: g.router, parent: g, prefix: g.path(prefix)}
+}
+
+// path returns the full path with the group prefix.
+func (g *Group) path(url string) string {
+	return strings.TrimSuffix(g.prefix, PathDelimeter) + url
+}
+
+// Endpoint describes the registered route of the HTTP router.
+type Endpoint struct {
+	Method     string       // метод запроса
+	Host       string       // хост или пустая строка для всех хостов
+	Pattern    string       // путь с параметрами в синтаксисе роутера, без хоста
+	Source     string       // путь с хостом в том виде, в котором был задан
+	handler    http.Handler // обработчик запроса
+	group      *Group       // группа, в которой зарегистрирован путь
+	middleware []Middleware // обработчики уровня пути
+	index      int          // порядковый номер в порядке добавления
+}
+
+// Use adds middleware at the level of this route only.
+func (e *Endpoint) Use(middleware ...Middleware) *Endpoint {
+	e.middleware = append(e.middleware, middleware...)
+	return e
+}
+
+// contextKey is used as a key for saving the information about the selected
+// route in the request context.
+type contextKey struct{}
+
+// routeContext describes the information about the selected route.
+type routeContext struct {
+	endpoint *Endpoint
+	match    *Match[*Endpoint]
+}
+
+// GetParams returns the values of named parameters of the route selected for
+// the request. Returns nil if the request was not processed by Router.
+func GetParams(req *http.Request) Params {
+	if rc, ok := req.Context().Value(contextKey{}).(*routeContext); ok {
+		return rc.match.Params
+	}
+	return nil
+}
+
+// GetMatch returns the full description of the route selected for the
+// request. Returns nil if the request was not processed by Router or no route
+// was found.
+func GetMatch(req *http.Request) *Match[*Endpoint] {
+	if rc, ok := req.Context().Value(contextKey{}).(*routeContext); ok {
+		return rc.match
+	}
+	return nil
+}
+
+// GetMeta returns the metadata of the route selected for the request. Returns
+// nil if the request was not processed by Router or the route has no
+// metadata.
+func GetMeta(req *http.Request) Meta {
+	if rc, ok := req.Context().Value(contextKey{}).(*routeContext); ok {
+		return rc.match.Meta
+	}
+	return nil
+}
+
+// GetPattern returns the pattern of the route selected for the request in the
+// syntax of the router, without the host. Returns an empty string if the
+// request was not processed by Router or no route was found. The pattern in
+// the form it was registered is available in the Source field of the endpoint
+// returned by GetMatch.
+func GetPattern(req *http.Request) string {
+	if rc, ok := req.Context().Value(contextKey{}).(*routeContext); ok {
+		return rc.endpoint.Pattern
+	}
+	return ""
+}
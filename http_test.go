@@ -0,0 +1,363 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRouterMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name+" "+GetPattern(r)+" "+GetParams(r).Get("id"))
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if match := GetMatch(r); match.Pattern != GetPattern(r) {
+			t.Errorf("bad match pattern: %v", match.Pattern)
+		}
+		calls = append(calls, "handler")
+	}
+
+	var r Router
+	r.Use(trace("router1"), trace("router2"))
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if GetMeta(r)["private"] == true {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	api := r.Group("/api/")
+	api.Use(trace("api"))
+	users := api.Group("/users")
+	endpoint, err := users.HandleFunc("GET", "/:id", handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint.Use(trace("route"))
+	users.Use(trace("users")) // добавлено после регистрации пути
+	if _, err := r.HandleFunc("GET", "/", handler); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.HandleFunc("GET", "/private", handler,
+		WithMeta(Meta{"private": true})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.HandleFunc("GET", "/test", nil); err == nil {
+		t.Error("add nil handler")
+	}
+	if _, err := r.Handle("GET", "/test", http.HandlerFunc(nil)); err == nil {
+		t.Error("add typed nil handler")
+	}
+	if _, err := r.Handle("GET", "/file/*name/test", http.NotFoundHandler()); err == nil {
+		t.Error("add bad catch all")
+	}
+
+	for _, test := range []struct {
+		URL    string
+		Status int
+		Calls  []string
+	}{
+		{"/api/users/42", 200, []string{
+			"router1 /api/users/:id 42",
+			"router2 /api/users/:id 42",
+			"api /api/users/:id 42",
+			"users /api/users/:id 42",
+			"route /api/users/:id 42",
+			"handler",
+		}},
+		{"/", 200, []string{"router1 / ", "router2 / ", "handler"}},
+		{"/missing", 404, []string{"router1  ", "router2  "}},
+		{"/api/private", 403, []string{"router1 /api/private ", "router2 /api/private "}},
+	} {
+		calls = nil
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", test.URL, nil))
+		if w.Code != test.Status {
+			t.Errorf("%v: bad status %v", test.URL, w.Code)
+		}
+		if !reflect.DeepEqual(calls, test.Calls) {
+			t.Errorf("%v: bad calls:\n%v", test.URL, strings.Join(calls, "\n"))
+		}
+	}
+}
+
+func TestRouterNotFound(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	var r Router
+	for _, method := range []string{"GET", "POST", "DELETE"} {
+		if _, err := r.HandleFunc(method, "/users/:id", handler); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := r.HandleFunc("GET", "/users", handler); err != nil {
+		t.Fatal(err)
+	}
+	if allowed := r.Allowed("/users/1"); !reflect.DeepEqual(allowed,
+		[]string{"DELETE", "GET", "POST"}) {
+		t.Errorf("bad allowed methods: %v", allowed)
+	}
+	if step, ok := r.Closest("GET", "/groups/1"); !ok || step.Handler.Pattern != "/users/:id" ||
+		step.Reason != SegmentMismatch || step.Segment != 0 {
+		t.Errorf("bad closest route: %v", step)
+	}
+	if _, ok := r.Closest("PUT", "/users/1"); ok {
+		t.Error("closest route for unknown method")
+	}
+
+	test := func(method, url string, status int, allow string) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, url, nil))
+		if w.Code != status {
+			t.Errorf("%v %v: bad status %v", method, url, w.Code)
+		}
+		if w.Header().Get("Allow") != allow {
+			t.Errorf("%v %v: bad allow header %q", method, url, w.Header().Get("Allow"))
+		}
+	}
+	test("PUT", "/users/1", 405, "DELETE, GET, POST")
+	test("POST", "/users", 405, "GET")
+	test("GET", "/missing", 404, "")
+
+	r.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	r.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	test("PUT", "/users/1", 418, "DELETE, GET, POST")
+	test("GET", "/missing", 410, "")
+}
+
+func TestRouterRoutes(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	var r Router
+	api := r.Group("/api")
+	tests := []Route[http.Handler]{
+		{Method: "GET", Pattern: "/api/users"},
+		{Method: "POST", Pattern: "/api/users", Meta: Meta{"scope": "write"}},
+		{Method: "GET", Pattern: "/api/users/:id"},
+		{Method: "DELETE", Pattern: "/api/users/:id", Meta: Meta{"scope": "write"}},
+		{Method: "GET", Pattern: "/"},
+	}
+	for _, test := range tests {
+		var err error
+		if test.Pattern == "/" {
+			_, err = r.HandleFunc(test.Method, test.Pattern, handler, WithMeta(test.Meta))
+		} else {
+			_, err = api.HandleFunc(test.Method, strings.TrimPrefix(test.Pattern, "/api"),
+				handler, WithMeta(test.Meta))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	routes := r.Routes()
+	if len(routes) != len(tests) {
+		t.Fatalf("bad routes count: %v", len(routes))
+	}
+	for i, route := range routes {
+		if route.Method != tests[i].Method || route.Pattern != tests[i].Pattern ||
+			route.Handler == nil || !reflect.DeepEqual(route.Meta, tests[i].Meta) {
+			t.Errorf("bad route: %v", route)
+		}
+	}
+	errStop := errors.New("stop")
+	if err := r.Walk(func(route Route[http.Handler]) error {
+		return errStop
+	}); err != errStop {
+		t.Errorf("bad walk error: %v", err)
+	}
+}
+
+func TestRouterDebugHandler(t *testing.T) {
+	var r Router
+	for _, method := range []string{"POST", "GET"} {
+		if _, err := r.HandleFunc(method, "/users/:id", http.NotFound); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := r.DebugHandler()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/debug/routes", nil))
+	table := "METHOD  PATTERN     HANDLER            META\n" +
+		"POST    /users/:id  net/http.NotFound  \n" +
+		"GET     /users/:id  net/http.NotFound  \n"
+	if w.Body.String() != table {
+		t.Errorf("bad routes table:\n%s", w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/debug/routes?tree", nil))
+	tree := "GET\n" +
+		"└── /users\n" +
+		"    └── /:id  [1 param] net/http.NotFound\n" +
+		"POST\n" +
+		"└── /users\n" +
+		"    └── /:id  [1 param] net/http.NotFound\n"
+	if w.Body.String() != tree {
+		t.Errorf("bad routes tree:\n%s", w.Body.String())
+	}
+}
+
+func TestRouterLoad(t *testing.T) {
+	registry := map[string]http.Handler{"user": http.NotFoundHandler()}
+	var r Router
+	if err := r.Load(strings.NewReader(`[
+	{"pattern": "/users/:id", "method": "GET", "target": "user"},
+	{"pattern": "/users/:id", "target": "user"}
+]`), registry); err == nil || err.Error() != "line 3: empty method" {
+		t.Errorf("bad load error: %v", err)
+	}
+	if err := r.Load(strings.NewReader(`[
+	{"pattern": "/users/:id", "method": "GET", "target": "user", "meta": {"scope": "read"}}
+]`), registry); err != nil {
+		t.Fatal(err)
+	}
+	if routes := r.Routes(); len(routes) != 1 || routes[0].Method != "GET" ||
+		routes[0].Meta["scope"] != "read" {
+		t.Errorf("bad loaded routes: %v", routes)
+	}
+	if err := r.Load(strings.NewReader(`{`), registry); err == nil {
+		t.Error("bad route file loaded")
+	}
+}
+
+func TestRouterServeMux(t *testing.T) {
+	var r Router
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(name + " " + req.PathValue("id") + " " + GetPattern(req)))
+		}
+	}
+	api := r.Group("/api")
+	for _, route := range []struct {
+		Method, Pattern, Name, Source string
+	}{
+		{"", "GET /users/{id}", "user", "/api/users/{id}"},
+		{"GET", "example.com/users/{id}", "host", "example.com/api/users/{id}"},
+		{"DELETE", "/users/{id}", "delete", "/api/users/{id}"},
+		{"", "POST example.com/users/{id}", "post", "example.com/api/users/{id}"},
+	} {
+		endpoint, err := api.Handle(route.Method, route.Pattern, handler(route.Name), WithServeMux())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if endpoint.Pattern != "/api/users/:id" || endpoint.Source != route.Source {
+			t.Errorf("bad endpoint patterns: %q, %q", endpoint.Pattern, endpoint.Source)
+		}
+	}
+	if _, err := r.Handle("POST", "GET /users", handler("bad"), WithServeMux()); err == nil {
+		t.Error("method mismatch added")
+	}
+	if _, err := r.Handle("", "/users", handler("bad"), WithServeMux()); err == nil {
+		t.Error("empty method added")
+	}
+	if _, err := r.Handle("", "/dead", handler("bad")); err == nil {
+		t.Error("empty method added without ServeMux")
+	}
+	for _, test := range []struct {
+		Method, URL string
+		Status      int
+		Body, Allow string
+	}{
+		{"GET", "http://localhost/api/users/1", 200, "user 1 /api/users/:id", ""},
+		{"GET", "http://example.com/api/users/2", 200, "host 2 /api/users/:id", ""},
+		{"GET", "http://example.com:8080/api/users/3", 200, "host 3 /api/users/:id", ""},
+		{"POST", "http://example.com/api/users/4", 200, "post 4 /api/users/:id", ""},
+		{"POST", "http://localhost/api/users/5", 405, "", "DELETE, GET"},
+		{"PUT", "http://example.com/api/users/6", 405, "", "DELETE, GET, POST"},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.Method, test.URL, nil))
+		if w.Code != test.Status || w.Header().Get("Allow") != test.Allow ||
+			(test.Body != "" && w.Body.String() != test.Body) {
+			t.Errorf("%v %v: bad response %v %q %q", test.Method, test.URL, w.Code,
+				w.Header().Get("Allow"), w.Body.String())
+		}
+	}
+	routes := r.Routes()
+	if len(routes) != 4 || routes[1].Host != "example.com" ||
+		routes[1].Pattern != "/api/users/:id" || routes[0].Host != "" {
+		t.Errorf("bad routes: %v", routes)
+	}
+	var buf strings.Builder
+	if err := r.WriteTree(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "GET example.com\n") {
+		t.Errorf("bad routes tree:\n%s", buf.String())
+	}
+}
+
+func TestRouterCoverage(t *testing.T) {
+	var r Router
+	coverage := r.Coverage()
+	for _, method := range []string{"GET", "DELETE"} {
+		if _, err := r.HandleFunc(method, "/users/:id", http.NotFound); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/1", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/users/1", nil))
+	report := coverage.Report()
+	if uncovered := report.Uncovered(); report.Covered != 1 || len(uncovered) != 1 ||
+		uncovered[0].Method != "DELETE" || uncovered[0].Pattern != "/users/:id" {
+		t.Errorf("bad coverage report: %+v", report)
+	}
+}
+
+func TestRouterLint(t *testing.T) {
+	var r Router
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	for _, route := range [][2]string{
+		{"GET", "/users/:id"},
+		{"DELETE", "/users/:user"},
+		{"GET", "/users/:user"},
+		{"GET", "example.com/users/{name}"},
+	} {
+		var opts []Option
+		if strings.Contains(route[1], "{") {
+			opts = append(opts, WithServeMux())
+		}
+		if _, err := r.HandleFunc(route[0], route[1], handler, opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var lines []string
+	for _, issue := range r.Lint() {
+		lines = append(lines, issue.String())
+	}
+	if want := []string{
+		"DELETE /users/:user: parameter :user differs from :id in GET /users/:id",
+		"GET /users/:user: parameter :user differs from :id in GET /users/:id",
+		"GET /users/:user: shadowed by /users/:id",
+	}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("bad issues:\n%v", strings.Join(lines, "\n"))
+	}
+}
+
+func TestRouterDiffRoutes(t *testing.T) {
+	var oldRouter, newRouter Router
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	if _, err := oldRouter.HandleFunc("GET", "/users/{id}", handler, WithServeMux()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newRouter.HandleFunc("GET", "/users/{name}", handler, WithServeMux()); err != nil {
+		t.Fatal(err)
+	}
+	diff, err := DiffRoutes(oldRouter.Routes(), newRouter.Routes())
+	if err != nil || diff.Breaking() ||
+		diff.String() != "~ GET /users/:id: parameter :id renamed to :name" {
+		t.Errorf("bad diff:\n%v %v", diff, err)
+	}
+}
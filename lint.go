@@ -0,0 +1,239 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LintKind describes the kind of the problem found by Lint.
+type LintKind uint8
+
+// The kinds of problems of routes.
+const (
+	// LintParamName means that routes with the same beginning of the path use
+	// different names of parameters at the same position: /users/:id and
+	// /users/:name/posts.
+	LintParamName LintKind = iota
+	// LintShadowed means that the route is never selected, because all its
+	// paths are matched by the route checked before it.
+	LintShadowed
+	// LintEmptyParam means that the parameter has no name: /users/:. Such
+	// routes can be added only with WithLaxSyntax.
+	LintEmptyParam
+	// LintDuplicateParam means that the name of the parameter is used in the
+	// pattern several times: /a/:id/:id. Such routes can be added only with
+	// WithLaxSyntax.
+	LintDuplicateParam
+	// LintTrailingSlash means that some routes end with a slash and others
+	// don't.
+	LintTrailingSlash
+)
+
+// String returns the name of the kind of the problem.
+func (k LintKind) String() string {
+	switch k {
+	case LintParamName:
+		return "param name"
+	case LintShadowed:
+		return "shadowed"
+	case LintEmptyParam:
+		return "empty param"
+	case LintDuplicateParam:
+		return "duplicate param"
+	case LintTrailingSlash:
+		return "trailing slash"
+	default:
+		return fmt.Sprintf("LintKind(%d)", k)
+	}
+}
+
+// LintIssue describes the problem of the route found by Lint.
+type LintIssue struct {
+	Kind    LintKind
+	Method  string // the request method; empty for Table
+	Pattern string // the pattern of the route with the problem and its host
+	Segment int    // the index of the path element with the problem or -1
+	Message string // the description of the problem
+
+	index int // порядковый номер пути в порядке добавления
+}
+
+// String returns the description of the problem in one line.
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", strings.TrimSpace(i.Method+" "+i.Pattern), i.Message)
+}
+
+// Lint checks the registered routes for consistency problems and returns them
+// in order of adding of routes. The problems don't prevent routing, but
+// usually are mistakes in the declaration of routes.
+func (r *Table[T]) Lint() []LintIssue {
+	routes, issues := lintTable(r, "", "", func(record *record[T]) (string, int) {
+		return record.pattern(), record.index
+	})
+	return sortIssues(append(issues, lintRoutes(routes)...))
+}
+
+// Lint checks the registered routes of all methods for consistency problems
+// and returns them in order of adding of routes. The shadowing of routes is
+// checked only among the routes with the same method and host.
+func (r *Router) Lint() []LintIssue {
+	var routes []*lintRoute
+	var issues []LintIssue
+	for key, paths := range r.methods {
+		list, shadowed := lintTable(paths, key.method, key.host,
+			func(record *record[*Endpoint]) (string, int) {
+				return record.handler.Host + record.handler.Pattern, record.handler.index
+			})
+		routes = append(routes, list...)
+		issues = append(issues, shadowed...)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].index < routes[j].index })
+	return sortIssues(append(issues, lintRoutes(routes)...))
+}
+
+// lintRoute describes the route for the checking.
+type lintRoute struct {
+	method, host string
+	pattern      string // шаблон пути для описания проблемы
+	parts        []string
+	segments     []Segment
+	serveMux     bool
+	index        int
+}
+
+// issue returns the problem of the route.
+func (l *lintRoute) issue(kind LintKind, segment int, format string, args ...interface{}) LintIssue {
+	return LintIssue{Kind: kind, Method: l.method, Pattern: l.pattern, Segment: segment,
+		Message: fmt.Sprintf(format, args...), index: l.index}
+}
+
+// lintTable returns the routes of the table in order of adding and the
+// problems of shadowed routes. The function describe returns the pattern and
+// the ordinal number of the route.
+func lintTable[T any](r *Table[T], method, host string,
+	describe func(*record[T]) (string, int)) ([]*lintRoute, []LintIssue) {
+	convert := func(record *record[T]) *lintRoute {
+		pattern, index := describe(record)
+		return &lintRoute{method: method, host: host, pattern: pattern,
+			parts: record.parts, segments: record.segments, serveMux: record.serveMux,
+			index: index}
+	}
+	var issues []LintIssue
+	// путь может быть перекрыт только путем той же длины, проверяемым раньше:
+	// более длинные пути не подходят для коротких запросов, а более короткие
+	// проверяются позже
+	for _, records := range r.fields {
+		for j, b := range records {
+			for _, a := range records[:j] {
+				if covers(a, b) {
+					other, _ := describe(a)
+					issues = append(issues,
+						convert(b).issue(LintShadowed, -1, "shadowed by %s", other))
+					break
+				}
+			}
+		}
+	}
+	routes := make([]*lintRoute, 0, r.count)
+	for _, record := range r.all() {
+		routes = append(routes, convert(record))
+	}
+	return routes, issues
+}
+
+// covers returns true if all paths matched by the route b are also matched by
+// the route a with the same number of path elements.
+func covers[T any](a, b *record[T]) bool {
+	// путь без catch-all параметра не подходит для более длинных запросов
+	if b.params>>15 == 1 && a.params>>15 != 1 {
+		return false
+	}
+	for i, part := range a.parts {
+		switch other := b.parts[i]; {
+		case strings.HasPrefix(part, CatchAllParamFlag):
+			return true
+		case strings.HasPrefix(part, NamedParamFlag):
+			// в синтаксисе http.ServeMux параметр не подходит для пустого
+			// элемента пути
+			if a.serveMux && (other == "" || (!isStatic(other) && !b.serveMux)) {
+				return false
+			}
+		case part != other:
+			return false
+		}
+	}
+	return true
+}
+
+// lintRoutes returns the problems of the declaration of routes: empty and
+// duplicate names of parameters, different names of parameters at the same
+// position and mixed styles of trailing slashes.
+func lintRoutes(routes []*lintRoute) []LintIssue {
+	var issues []LintIssue
+	first := make(map[string]*lintRoute) // первый путь с параметром в позиции
+	var slashed, unslashed []*lintRoute
+	for _, route := range routes {
+		names := make(map[string]bool)
+		for i, part := range route.parts {
+			if isStatic(part) {
+				continue
+			}
+			name := part[1:]
+			switch {
+			case name == "":
+				// безымянный catch-all параметр используется для путей
+				// http.ServeMux, оканчивающихся на слеш
+				if !route.serveMux || part != CatchAllParamFlag {
+					issues = append(issues,
+						route.issue(LintEmptyParam, i, "parameter %s without name", part))
+				}
+				continue
+			case names[name]:
+				issues = append(issues,
+					route.issue(LintDuplicateParam, i, "duplicate parameter %s", part))
+			}
+			names[name] = true
+			// пути с одинаковым началом сравниваем без учета имен параметров
+			key := route.host + shape(route.segments[:i+1])
+			other, ok := first[key]
+			if !ok {
+				first[key] = route
+				continue
+			}
+			if other.parts[i] != part {
+				issues = append(issues, route.issue(LintParamName, i,
+					"parameter %s differs from %s in %s", part, other.parts[i],
+					strings.TrimSpace(other.method+" "+other.pattern)))
+			}
+		}
+		if len(route.parts) > 1 && route.parts[len(route.parts)-1] == "" {
+			slashed = append(slashed, route)
+		} else if len(route.parts) > 1 || route.parts[0] != "" {
+			unslashed = append(unslashed, route)
+		}
+	}
+	// сообщаем о путях, использующих менее распространенный стиль
+	if len(slashed) > 0 && len(unslashed) > 0 {
+		minority, count, style := slashed, len(unslashed), "with"
+		if len(unslashed) < len(slashed) {
+			minority, count, style = unslashed, len(slashed), "without"
+		}
+		for _, route := range minority {
+			issues = append(issues, route.issue(LintTrailingSlash, -1,
+				"ends %s a slash unlike %d other routes", style, count))
+		}
+	}
+	return issues
+}
+
+// sortIssues sorts the problems in order of adding of routes and by kind.
+func sortIssues(issues []LintIssue) []LintIssue {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].index != issues[j].index {
+			return issues[i].index < issues[j].index
+		}
+		return issues[i].Kind < issues[j].Kind
+	})
+	return issues
+}
@@ -0,0 +1,211 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// RouteEntry describes a single route in the route file.
+type RouteEntry struct {
+	Pattern string `json:"pattern"`          // the pattern of the route
+	Method  string `json:"method,omitempty"` // the request method
+	Target  string `json:"target"`           // the name of the handler
+	Meta    Meta   `json:"meta,omitempty"`   // the metadata of the route
+
+	line int // номер строки в файле с описанием
+}
+
+// LoadError describes an error in the route file.
+type LoadError struct {
+	Line int   // the line number in the route file
+	Err  error // the error
+}
+
+// Error returns the description of the error with the line number.
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the original error.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadErrors describes the list of errors in the route file.
+type LoadErrors []*LoadError
+
+// Error returns the descriptions of all errors, one per line.
+func (e LoadErrors) Error() string {
+	list := make([]string, len(e))
+	for i, err := range e {
+		list[i] = err.Error()
+	}
+	return strings.Join(list, "\n")
+}
+
+// Unwrap returns the list of errors.
+func (e LoadErrors) Unwrap() []error {
+	list := make([]error, len(e))
+	for i, err := range e {
+		list[i] = err
+	}
+	return list
+}
+
+// ReadRoutes reads the route file. The route file is a JSON array of objects
+// with the fields "pattern", "method", "target" and "meta":
+//
+//	[
+//		{"pattern": "/users", "method": "GET", "target": "listUsers"},
+//		{"pattern": "/users/:id", "method": "GET", "target": "getUser",
+//			"meta": {"scope": "read"}}
+//	]
+//
+// The file syntax errors are returned as LoadErrors with the line number. Any
+// data after the array is an error too.
+func ReadRoutes(r io.Reader) ([]RouteEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	// синтаксические ошибки возвращаем с номером строки; смещение ошибки типа
+	// отсчитывается от начала описания пути
+	var start int64
+	fail := func(err error) error {
+		var offset int64
+		var syntax *json.SyntaxError
+		var unmarshal *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntax):
+			offset = syntax.Offset
+		case errors.As(err, &unmarshal):
+			offset = start + unmarshal.Offset
+		default:
+			offset = dec.InputOffset()
+		}
+		return LoadErrors{{Line: line(data, offset), Err: err}}
+	}
+	if token, err := dec.Token(); err != nil {
+		return nil, fail(err)
+	} else if token != json.Delim('[') {
+		return nil, fail(errors.New("route file must be an array"))
+	}
+	var entries []RouteEntry
+	for dec.More() {
+		// пропускаем разделители, чтобы получить начало описания пути
+		offset := dec.InputOffset()
+		for offset < int64(len(data)) && bytes.IndexByte([]byte(" \t\r\n,"), data[offset]) >= 0 {
+			offset++
+		}
+		start = offset
+		var entry RouteEntry
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fail(err)
+		}
+		entry.line = line(data, offset)
+		entries = append(entries, entry)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fail(err)
+	}
+	// после списка путей ничего быть не должно
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("unexpected data after the route list")
+		}
+		return nil, fail(err)
+	}
+	return entries, nil
+}
+
+// line returns the line number for the offset in the data.
+func line(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// Load reads the route file and adds all its routes. The handlers of routes are
+// selected from the registry by the target name. The format of the file is
+// described in ReadRoutes. The route file for Table must not contain methods.
+//
+// All routes are checked before adding: the target must be registered and the
+// pattern must pass the same checks as in Add. If there are errors, no routes
+// are added and the errors are returned as LoadErrors with the line numbers.
+func (r *Table[T]) Load(rd io.Reader, registry map[string]T) error {
+	entries, err := ReadRoutes(rd)
+	if err != nil {
+		return err
+	}
+	var errs LoadErrors
+	for _, entry := range entries {
+		if entry.Method != "" {
+			errs = append(errs, &LoadError{Line: entry.line,
+				Err: fmt.Errorf("method is not supported: %s", entry.Method)})
+		}
+		if err := check(entry, registry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	for _, entry := range entries {
+		if err := r.Add(entry.Pattern, registry[entry.Target], WithMeta(entry.Meta)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load reads the route file and registers all its routes. The handlers of
+// routes are selected from the registry by the target name. Each route in the
+// file must have a method. Otherwise it works in the same way as Table.Load.
+func (r *Router) Load(rd io.Reader, registry map[string]http.Handler) error {
+	entries, err := ReadRoutes(rd)
+	if err != nil {
+		return err
+	}
+	var errs LoadErrors
+	for _, entry := range entries {
+		if entry.Method == "" {
+			errs = append(errs, &LoadError{Line: entry.line, Err: errors.New("empty method")})
+		}
+		if err := check(entry, registry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	for _, entry := range entries {
+		if _, err := r.Handle(entry.Method, entry.Pattern, registry[entry.Target],
+			WithMeta(entry.Meta)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check returns an error if the pattern of the route is not valid or the
+// handler of the route is not registered.
+func check[T any](entry RouteEntry, registry map[string]T) *LoadError {
+	if entry.Pattern == "" {
+		return &LoadError{Line: entry.line, Err: errors.New("empty pattern")}
+	}
+	if _, err := ParsePattern(entry.Pattern); err != nil {
+		return &LoadError{Line: entry.line, Err: err}
+	}
+	if handler, ok := registry[entry.Target]; !ok || isNil(handler) {
+		return &LoadError{Line: entry.line, Err: fmt.Errorf("unknown target: %q", entry.Target)}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package router
+
+import "fmt"
+
+// Meta describes arbitrary metadata attached to the route when adding it: tags,
+// access scopes, the rate limit class, description, deprecation flag or
+// anything else. The router does not interpret it and only returns it with the
+// selected route, so middleware can make decisions from the route declaration.
+type Meta map[string]interface{}
+
+// Option describes an additional setting of the route used when adding it.
+type Option func(*options)
+
+// options contains the additional settings of the route.
+type options struct {
+	meta     Meta // метаданные пути
+	priority int  // приоритет среди путей той же длины
+	serveMux bool // путь задан в синтаксисе http.ServeMux
+	lax      bool // не проверять имена параметров
+}
+
+// WithMeta attaches metadata to the route. When used several times, the values
+// are merged and the later ones replace the earlier with the same key.
+func WithMeta(meta Meta) Option {
+	return func(o *options) {
+		if len(meta) == 0 {
+			return
+		}
+		if o.meta == nil {
+			o.meta = make(Meta, len(meta))
+		}
+		for key, value := range meta {
+			o.meta[key] = value
+		}
+	}
+}
+
+// WithPriority sets the priority of the route. Among the routes with parameters
+// and the same number of path elements the routes with the higher priority are
+// checked first. By default the priority is zero, negative values lower it.
+// Routes with a catch-all parameter are always checked after the others of the
+// same length, whatever their priority.
+func WithPriority(priority int) Option {
+	return func(o *options) {
+		o.priority = priority
+	}
+}
+
+// Match describes the route selected for the path.
+type Match[T any] struct {
+	Handler T         // the handler of the route
+	Params  Params    // the values of named parameters
+	Meta    Meta      // the metadata of the route
+	Pattern string    // the pattern of the route
+	Parts   []string  // the pattern disassembled into its component parts
+	Index   int       // the ordinal number of the route in order of adding
+	Kind    MatchKind // the kind of the route
+}
+
+// MatchKind describes the kind of the selected route.
+type MatchKind uint8
+
+// The kinds of routes.
+const (
+	MatchStatic   MatchKind = iota // static path without parameters
+	MatchParam                     // path with named parameters
+	MatchCatchAll                  // path with the final catch-all parameter
+)
+
+// String returns the name of the kind of route.
+func (k MatchKind) String() string {
+	switch k {
+	case MatchStatic:
+		return "static"
+	case MatchParam:
+		return "param"
+	case MatchCatchAll:
+		return "catch-all"
+	default:
+		return fmt.Sprintf("MatchKind(%d)", k)
+	}
+}
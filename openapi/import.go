@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mdigger/router"
+)
+
+// methods contains the names of operations of the path item, which describe
+// request methods.
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true,
+	"head": true, "patch": true, "trace": true,
+}
+
+// UnmarshalJSON decodes the path item, skipping all its fields except the
+// operations, for example, common parameters or references.
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	item := make(PathItem, len(fields))
+	for name, value := range fields {
+		if !methods[name] {
+			continue
+		}
+		operation := new(Operation)
+		if err := json.Unmarshal(value, operation); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		item[name] = operation
+	}
+	*p = item
+	return nil
+}
+
+// Decode reads the OpenAPI document in JSON format.
+func Decode(r io.Reader) (*Document, error) {
+	doc := new(Document)
+	if err := json.NewDecoder(r).Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Report describes the result of the import.
+type Report struct {
+	// Unbound contains the operations without handlers in the form of
+	// "METHOD /path operationId".
+	Unbound []string
+	// Unused contains the names of handlers without operations.
+	Unused []string
+}
+
+// Import registers the operations of the document in the router. The handler
+// of the operation is selected from the map by its operationId. Path template
+// parameters are converted to named parameters: `{id}` to `:id`, and the
+// parameter with the x-catch-all extension to the catch-all parameter. The
+// operationId, summary, description, tags and deprecation flag are saved in
+// the route metadata with the same keys as used by Export.
+//
+// Operations without handlers are not registered and, like the handlers
+// without operations, are listed in the report. Returns an error if the path
+// template can not be converted or the route can not be added.
+func Import(doc *Document, r *router.Router, handlers map[string]http.Handler) (*Report, error) {
+	report := new(Report)
+	used := make(map[string]bool, len(handlers))
+	// перебираем пути и операции в алфавитном порядке
+	templates := make([]string, 0, len(doc.Paths))
+	for template := range doc.Paths {
+		templates = append(templates, template)
+	}
+	sort.Strings(templates)
+	for _, template := range templates {
+		item := doc.Paths[template]
+		names := make([]string, 0, len(item))
+		for name := range item {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			operation := item[name]
+			method := strings.ToUpper(name)
+			handler, ok := handlers[operation.OperationID]
+			if !ok || operation.OperationID == "" {
+				report.Unbound = append(report.Unbound, strings.TrimSpace(
+					method+" "+template+" "+operation.OperationID))
+				continue
+			}
+			used[operation.OperationID] = true
+			pattern, err := parse(template, operation.Parameters)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := r.Handle(method, pattern, handler,
+				router.WithMeta(meta(operation))); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, template, err)
+			}
+		}
+	}
+	for name := range handlers {
+		if !used[name] {
+			report.Unused = append(report.Unused, name)
+		}
+	}
+	sort.Strings(report.Unused)
+	return report, nil
+}
+
+// parse returns the pattern of the route for the path template.
+func parse(template string, params []*Parameter) (string, error) {
+	parts := strings.Split(template, "/")
+	for i, part := range parts {
+		if !strings.ContainsAny(part, "{}") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(part, "{"), "}")
+		if name == "" || strings.ContainsAny(name, "{}") {
+			return "", fmt.Errorf("unsupported path template: %s", template)
+		}
+		flag := router.NamedParamFlag
+		for _, param := range params {
+			if param.Name == name && param.In == "path" && param.CatchAll {
+				flag = router.CatchAllParamFlag
+			}
+		}
+		parts[i] = flag + name
+	}
+	return strings.Join(parts, router.PathDelimeter), nil
+}
+
+// meta returns the route metadata with the description of the operation.
+func meta(operation *Operation) router.Meta {
+	meta := make(router.Meta)
+	if operation.OperationID != "" {
+		meta[MetaOperationID] = operation.OperationID
+	}
+	if operation.Summary != "" {
+		meta[MetaSummary] = operation.Summary
+	}
+	if operation.Description != "" {
+		meta[MetaDescription] = operation.Description
+	}
+	if len(operation.Tags) > 0 {
+		meta[MetaTags] = operation.Tags
+	}
+	if operation.Deprecated {
+		meta[MetaDeprecated] = true
+	}
+	return meta
+}
@@ -0,0 +1,239 @@
+// Package openapi converts route tables of the router package to the paths of
+// OpenAPI 3 specification.
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mdigger/router"
+)
+
+// Version is the version of OpenAPI specification used for new documents.
+const Version = "3.0.3"
+
+// Document describes the OpenAPI document. Only the fields used for the
+// description of paths are supported.
+type Document struct {
+	OpenAPI string `json:"openapi"`
+	Info    Info   `json:"info"`
+	Paths   Paths  `json:"paths"`
+}
+
+// Info describes the information about the API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Paths describes the paths of the API. The path template is used as the key.
+type Paths map[string]PathItem
+
+// PathItem describes the operations available on a single path. The name of
+// the request method in lower case is used as the key.
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a single parameter of the operation. The extension
+// x-catch-all marks the parameter, which takes the rest of the path.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema,omitempty"`
+	CatchAll    bool    `json:"x-catch-all,omitempty"`
+}
+
+// Schema describes the type of the parameter value.
+type Schema struct {
+	Type string `json:"type"`
+}
+
+// Response describes a single response of the operation.
+type Response struct {
+	Description string `json:"description"`
+}
+
+// The keys of route metadata used for the description of operations.
+const (
+	MetaOperationID = "operationId" // string
+	MetaSummary     = "summary"     // string
+	MetaDescription = "description" // string
+	MetaTags        = "tags"        // string or []string
+	MetaDeprecated  = "deprecated"  // bool
+)
+
+// Export returns the description of paths for the list of routes. Named
+// parameters are converted to the path template parameters: `:name` to
+// `{name}`. The catch-all parameter is converted in the same way and is
+// described as a string, which may contain slashes, and marked with the
+// x-catch-all extension. The catch-all parameter without a name, which
+// WithServeMux uses for patterns ending with a slash, is named "path". The
+// patterns in the syntax of http.ServeMux are converted too. Routes without a
+// method are described as GET operations.
+//
+// The summary, description, tags, deprecation flag and operationId of the
+// operation are taken from the route metadata with the keys described above.
+// Metadata with other keys is ignored.
+//
+// The paths of OpenAPI don't contain the host, so the routes for separate hosts
+// are described together with the routes for all hosts. Returns an error if
+// two routes have the same method and path template, for example, the routes
+// for different hosts, or if the path templates differ only by the names of
+// parameters, like /users/{id} and /users/{name}, as the specification forbids
+// such paths.
+func Export[T any](routes []router.Route[T]) (Paths, error) {
+	paths := make(Paths)
+	sources := make(map[string]string)   // описания путей по шаблону и методу
+	templates := make(map[string]string) // шаблоны путей без имен параметров
+	for _, route := range routes {
+		template, params := convert(route.Pattern)
+		method := strings.ToLower(route.Method)
+		if method == "" {
+			method = "get"
+		}
+		source := strings.TrimSpace(route.Method + " " + route.Host + route.Pattern)
+		shape := template
+		for _, param := range params {
+			shape = strings.Replace(shape, "{"+param.Name+"}", "{}", 1)
+		}
+		if other, ok := templates[shape]; ok && other != template {
+			return nil, fmt.Errorf("%s: path template %s conflicts with %s",
+				source, template, other)
+		}
+		templates[shape] = template
+		if other, ok := sources[method+" "+template]; ok {
+			return nil, fmt.Errorf("%s: operation %s %s is already described by %s",
+				source, strings.ToUpper(method), template, other)
+		}
+		sources[method+" "+template] = source
+		item := paths[template]
+		if item == nil {
+			item = make(PathItem)
+			paths[template] = item
+		}
+		operation := &Operation{
+			Parameters: params,
+			Responses: map[string]*Response{
+				"default": {Description: "Default response"},
+			},
+		}
+		describe(operation, route.Meta)
+		item[method] = operation
+	}
+	return paths, nil
+}
+
+// NewDocument returns the OpenAPI document with the description of paths for
+// the list of routes. The errors are the same as for Export.
+func NewDocument[T any](title, version string, routes []router.Route[T]) (*Document, error) {
+	paths, err := Export(routes)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Paths:   paths,
+	}, nil
+}
+
+// convert returns the path template for the pattern of the route and the
+// description of its parameters. If the name "path" for the catch-all
+// parameter without a name is taken, a number is added to it.
+func convert(pattern string) (string, []*Parameter) {
+	var (
+		params    []*Parameter
+		positions []int // индексы элементов пути с параметрами
+	)
+	names := make(map[string]bool)
+	parts := strings.Split(pattern, router.PathDelimeter)
+	for i, part := range parts {
+		var (
+			description string
+			catchAll    bool
+		)
+		switch {
+		case strings.HasPrefix(part, router.NamedParamFlag):
+			part = strings.TrimPrefix(part, router.NamedParamFlag)
+		case strings.HasPrefix(part, router.CatchAllParamFlag):
+			part = strings.TrimPrefix(part, router.CatchAllParamFlag)
+			catchAll = true
+		case part == "{$}":
+			parts[i] = ""
+			continue
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "...}"):
+			part = part[1 : len(part)-4]
+			catchAll = true
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			part = part[1 : len(part)-1]
+		default:
+			continue
+		}
+		if catchAll {
+			description = "The rest of the path, may contain slashes."
+		}
+		params = append(params, &Parameter{
+			Name:        part,
+			In:          "path",
+			Description: description,
+			Required:    true,
+			Schema:      &Schema{Type: "string"},
+			CatchAll:    catchAll,
+		})
+		positions = append(positions, i)
+		names[part] = true
+	}
+	// даем имя безымянному catch-all параметру
+	for _, param := range params {
+		if param.Name != "" {
+			continue
+		}
+		name := "path"
+		for n := 1; names[name]; n++ {
+			name = fmt.Sprintf("path%d", n)
+		}
+		param.Name, names[name] = name, true
+	}
+	for i, param := range params {
+		parts[positions[i]] = "{" + param.Name + "}"
+	}
+	return strings.Join(parts, "/"), params
+}
+
+// describe fills the description of the operation from the route metadata.
+func describe(operation *Operation, meta router.Meta) {
+	if value, ok := meta[MetaOperationID].(string); ok {
+		operation.OperationID = value
+	}
+	if value, ok := meta[MetaSummary].(string); ok {
+		operation.Summary = value
+	}
+	if value, ok := meta[MetaDescription].(string); ok {
+		operation.Description = value
+	}
+	if value, ok := meta[MetaDeprecated].(bool); ok {
+		operation.Deprecated = value
+	}
+	switch value := meta[MetaTags].(type) {
+	case string:
+		operation.Tags = []string{value}
+	case []string:
+		operation.Tags = value
+	case []interface{}:
+		for _, tag := range value {
+			operation.Tags = append(operation.Tags, fmt.Sprint(tag))
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mdigger/router"
+)
+
+func TestExport(t *testing.T) {
+	var r router.Router
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	for _, route := range []router.Route[http.Handler]{
+		{Method: "GET", Pattern: "/users", Meta: router.Meta{
+			MetaOperationID: "listUsers",
+			MetaSummary:     "List users",
+			MetaTags:        "users",
+		}},
+		{Method: "GET", Pattern: "/users/:id", Meta: router.Meta{
+			MetaOperationID: "getUser",
+			MetaDescription: "Returns the user.",
+			MetaTags:        []string{"users", "public"},
+			MetaDeprecated:  true,
+		}},
+		{Method: "DELETE", Pattern: "/users/:id", Meta: router.Meta{
+			MetaTags: []interface{}{"users", 1},
+		}},
+		{Method: "GET", Pattern: "/files/*path"},
+	} {
+		if _, err := r.HandleFunc(route.Method, route.Pattern, handler,
+			router.WithMeta(route.Meta)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	doc, err := NewDocument("Test", "1.0", r.Routes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{
+	"openapi": "3.0.3",
+	"info": {
+		"title": "Test",
+		"version": "1.0"
+	},
+	"paths": {
+		"/files/{path}": {
+			"get": {
+				"parameters": [
+					{
+						"name": "path",
+						"in": "path",
+						"description": "The rest of the path, may contain slashes.",
+						"required": true,
+						"schema": {
+							"type": "string"
+						},
+						"x-catch-all": true
+					}
+				],
+				"responses": {
+					"default": {
+						"description": "Default response"
+					}
+				}
+			}
+		},
+		"/users": {
+			"get": {
+				"operationId": "listUsers",
+				"summary": "List users",
+				"tags": [
+					"users"
+				],
+				"responses": {
+					"default": {
+						"description": "Default response"
+					}
+				}
+			}
+		},
+		"/users/{id}": {
+			"delete": {
+				"tags": [
+					"users",
+					"1"
+				],
+				"parameters": [
+					{
+						"name": "id",
+						"in": "path",
+						"required": true,
+						"schema": {
+							"type": "string"
+						}
+					}
+				],
+				"responses": {
+					"default": {
+						"description": "Default response"
+					}
+				}
+			},
+			"get": {
+				"operationId": "getUser",
+				"description": "Returns the user.",
+				"tags": [
+					"users",
+					"public"
+				],
+				"deprecated": true,
+				"parameters": [
+					{
+						"name": "id",
+						"in": "path",
+						"required": true,
+						"schema": {
+							"type": "string"
+						}
+					}
+				],
+				"responses": {
+					"default": {
+						"description": "Default response"
+					}
+				}
+			}
+		}
+	}
+}`
+	if string(data) != want {
+		t.Errorf("bad document:\n%s", data)
+	}
+
+	var paths router.Paths
+	if err := paths.Add("/", 1); err != nil {
+		t.Fatal(err)
+	}
+	if exported, err := Export(paths.Routes()); err != nil || exported["/"]["get"] == nil {
+		t.Errorf("bad route without method: %v %v", exported, err)
+	}
+
+	// пути в синтаксисе http.ServeMux
+	var mux router.Router
+	for _, pattern := range []string{"GET /static/", "GET /files/{path...}", "GET /path/{path}/"} {
+		if _, err := mux.HandleFunc("", pattern, handler, router.WithServeMux()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, test := range []struct {
+		Pattern, Template, Names string
+	}{
+		{"/static/*", "/static/{path}", "path"},
+		{"/path/:path/*", "/path/{path}/{path1}", "path path1"},
+		{"/files/{path...}", "/files/{path}", "path"},
+		{"/users/{id}/{$}", "/users/{id}/", "id"},
+	} {
+		template, params := convert(test.Pattern)
+		var names []string
+		for _, param := range params {
+			names = append(names, param.Name)
+		}
+		if template != test.Template || strings.Join(names, " ") != test.Names {
+			t.Errorf("%v: bad template %v %v", test.Pattern, template, names)
+		}
+	}
+	if paths, err := Export(mux.Routes()); err != nil || paths["/static/{path}"]["get"] == nil ||
+		paths["/files/{path}"]["get"] == nil || paths["/path/{path}/{path1}"]["get"] == nil {
+		t.Errorf("bad ServeMux paths: %v %v", paths, err)
+	}
+
+	// конфликтующие пути
+	for _, test := range []struct {
+		Routes []router.Route[int]
+		Error  string
+	}{
+		{[]router.Route[int]{
+			{Method: "GET", Host: "a.com", Pattern: "/x"},
+			{Method: "GET", Pattern: "/x"},
+		}, "GET /x: operation GET /x is already described by GET a.com/x"},
+		{[]router.Route[int]{
+			{Method: "GET", Pattern: "/u/:id"},
+			{Method: "DELETE", Pattern: "/u/:name"},
+		}, "DELETE /u/:name: path template /u/{name} conflicts with /u/{id}"},
+	} {
+		if _, err := Export(test.Routes); err == nil || err.Error() != test.Error {
+			t.Errorf("bad conflict error: %v", err)
+		}
+		if _, err := NewDocument("Test", "1.0", test.Routes); err == nil {
+			t.Errorf("bad document for conflicting routes: %v", test.Routes)
+		}
+	}
+	if _, err := Export([]router.Route[int]{
+		{Method: "GET", Host: "a.com", Pattern: "/x"},
+		{Method: "POST", Pattern: "/x"},
+	}); err != nil {
+		t.Errorf("bad host routes merging: %v", err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	const spec = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Test", "version": "1.0"},
+	"paths": {
+		"/users": {
+			"summary": "Users",
+			"parameters": [],
+			"get": {"operationId": "listUsers", "tags": ["users"]},
+			"post": {"operationId": "createUser"}
+		},
+		"/users/{id}": {
+			"get": {
+				"operationId": "getUser",
+				"summary": "Get user",
+				"description": "Returns the user.",
+				"deprecated": true,
+				"parameters": [{"name": "id", "in": "path", "required": true}]
+			},
+			"delete": {}
+		},
+		"/files/{path}": {
+			"get": {
+				"operationId": "getFile",
+				"parameters": [{"name": "path", "in": "path", "x-catch-all": true}]
+			}
+		}
+	}
+}`
+	doc, err := Decode(strings.NewReader(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handlers := map[string]http.Handler{
+		"listUsers": handler,
+		"getUser":   handler,
+		"getFile":   handler,
+		"unused":    handler,
+	}
+	var r router.Router
+	report, err := Import(doc, &r, handlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report, &Report{
+		Unbound: []string{"POST /users createUser", "DELETE /users/{id}"},
+		Unused:  []string{"unused"},
+	}) {
+		t.Errorf("bad report: %+v", report)
+	}
+	var routes []string
+	for _, route := range r.Routes() {
+		routes = append(routes, fmt.Sprintf("%s %s %v", route.Method, route.Pattern, route.Meta))
+	}
+	if want := []string{
+		"GET /files/*path map[operationId:getFile]",
+		"GET /users map[operationId:listUsers tags:[users]]",
+		"GET /users/:id map[deprecated:true description:Returns the user. operationId:getUser summary:Get user]",
+	}; !reflect.DeepEqual(routes, want) {
+		t.Errorf("bad routes:\n%s", strings.Join(routes, "\n"))
+	}
+	// экспорт импортированных путей должен совпадать с исходным описанием
+	paths, err := Export(r.Routes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation := paths["/files/{path}"]["get"]; operation == nil ||
+		!operation.Parameters[0].CatchAll {
+		t.Errorf("bad exported catch-all: %v", operation)
+	}
+
+	for _, template := range []string{"/users/{id}.json", "/users/{}", "/{{id}}"} {
+		doc := &Document{Paths: Paths{template: PathItem{
+			"get": &Operation{OperationID: "getUser"},
+		}}}
+		if _, err := Import(doc, new(router.Router), handlers); err == nil {
+			t.Errorf("bad template imported: %v", template)
+		}
+	}
+	doc = &Document{Paths: Paths{"/users/me": PathItem{
+		"get": &Operation{OperationID: "getUser"},
+	}}}
+	if _, err := Import(doc, new(router.Router), handlers); err != nil {
+		t.Errorf("bad static template: %v", err)
+	}
+	doc = &Document{Paths: Paths{"/users/*": PathItem{
+		"get": &Operation{OperationID: "getUser"},
+	}}}
+	var patternErr *router.PatternError
+	if _, err := Import(doc, new(router.Router), handlers); !errors.As(err, &patternErr) {
+		t.Errorf("bad pattern error: %v", err)
+	}
+	for _, spec := range []string{`{"paths": {"/": []}}`, `{"paths": {"/": {"get": []}}}`} {
+		if _, err := Decode(strings.NewReader(spec)); err == nil {
+			t.Errorf("bad document decoded: %v", spec)
+		}
+	}
+}
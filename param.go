@@ -23,10 +23,17 @@ type Params []Param
 // Get returns the value of the first parameter in the list with the specified
 // name. If such a parameter is not listed, it returns the empty string.
 func (p Params) Get(name string) string {
+	value, _ := p.lookup(name)
+	return value
+}
+
+// lookup returns the value of the first parameter in the list with the
+// specified name and true if such a parameter is listed.
+func (p Params) lookup(name string) (string, bool) {
 	for _, param := range p {
 		if param.Key == name {
-			return param.Value
+			return param.Value, true
 		}
 	}
-	return ""
+	return "", false
 }
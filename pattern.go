@@ -0,0 +1,261 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SegmentKind describes the kind of the path element of the pattern.
+type SegmentKind uint8
+
+// The kinds of path elements.
+const (
+	SegmentStatic   SegmentKind = iota // static text
+	SegmentParam                       // named parameter, starts with ':'
+	SegmentCatchAll                    // catch-all parameter, starts with '*'
+)
+
+// String returns the name of the kind of the path element.
+func (k SegmentKind) String() string {
+	switch k {
+	case SegmentStatic:
+		return "static"
+	case SegmentParam:
+		return "param"
+	case SegmentCatchAll:
+		return "catch-all"
+	default:
+		return fmt.Sprintf("SegmentKind(%d)", k)
+	}
+}
+
+// Segment describes a single path element of the pattern.
+type Segment struct {
+	Kind  SegmentKind
+	Value string // the static text or the name of the parameter without the flag
+}
+
+// String returns the path element in the syntax of the router.
+func (s Segment) String() string {
+	switch s.Kind {
+	case SegmentParam:
+		return NamedParamFlag + s.Value
+	case SegmentCatchAll:
+		return CatchAllParamFlag + s.Value
+	default:
+		return s.Value
+	}
+}
+
+// Pattern describes the parsed pattern of the route. It can be used to match
+// and build paths of a single route without Table.
+type Pattern struct {
+	Segments []Segment // the path elements of the pattern
+
+	parts    []string // элементы пути в исходном виде
+	params   uint16   // количество параметров и флаг catch-all параметра
+	serveMux bool     // параметр не может быть пустым
+}
+
+// The errors of the pattern syntax returned in PatternError.
+var (
+	// ErrEmptyParamName means that the parameter has no name: /users/:.
+	ErrEmptyParamName = errors.New("empty parameter name")
+	// ErrDuplicateParam means that the name of the parameter is used in the
+	// pattern several times: /a/:id/:id.
+	ErrDuplicateParam = errors.New("duplicate parameter name")
+	// ErrBadParamName means that the name of the parameter starts with the flag
+	// of a parameter: /a/::id.
+	ErrBadParamName = errors.New("bad parameter name")
+	// ErrCatchAllNotLast means that the catch-all parameter is not the last
+	// element of the pattern: /files/*name/info.
+	ErrCatchAllNotLast = errors.New("catch-all parameter must be last")
+)
+
+// PatternError describes the syntax error of the pattern of the route.
+type PatternError struct {
+	Pattern string // the pattern of the route
+	Segment int    // the index of the path element with the error
+	Err     error  // the error, one of ErrEmptyParamName, ErrDuplicateParam, ...
+}
+
+// Error returns the description of the error with the pattern and the path
+// element.
+func (e *PatternError) Error() string {
+	var part string
+	if parts := splitter(e.Pattern); e.Segment >= 0 && e.Segment < len(parts) {
+		part = parts[e.Segment]
+	}
+	return fmt.Sprintf("%s: %v: %s at %d", e.Pattern, e.Err, part, e.Segment)
+}
+
+// Unwrap returns the original error.
+func (e *PatternError) Unwrap() error {
+	return e.Err
+}
+
+// WithLaxSyntax disables the checking of names of parameters when adding the
+// route, so the empty and duplicate names and names starting with ':' or '*'
+// are allowed, as in the earlier versions of the router. The values of such
+// parameters can be obtained only by their position in Params.
+func WithLaxSyntax() Option {
+	return func(o *options) {
+		o.lax = true
+	}
+}
+
+// ParsePattern parses the pattern in the syntax of the router, which is used by
+// Table.Add, and returns it as a list of typed path elements. The names of
+// parameters are checked in the same way as by Add: in case of an error
+// PatternError is returned.
+func ParsePattern(pattern string) (*Pattern, error) {
+	return parsePattern(pattern, options{})
+}
+
+// parsePattern parses the pattern with the specified settings of the route.
+// Unless the lax syntax is set, the names of parameters are checked too.
+func parsePattern(pattern string, o options) (*Pattern, error) {
+	parts := splitter(pattern) // нормализуем путь и разбиваем его на части
+	// проверяем, что количество получившихся частей не превышает максимально
+	// поддерживаемое количество
+	if len(parts) > (1<<15 - 1) {
+		return nil, fmt.Errorf("%s: path parts overflow: %d", pattern, len(parts))
+	}
+	p := &Pattern{Segments: make([]Segment, len(parts)), parts: parts, serveMux: o.serveMux}
+	var names map[string]bool // имена параметров для проверки повторов
+	for i, value := range parts {
+		segment := Segment{Kind: SegmentStatic, Value: value}
+		switch {
+		case strings.HasPrefix(value, NamedParamFlag):
+			segment = Segment{Kind: SegmentParam, Value: value[len(NamedParamFlag):]}
+			p.params++ // увеличиваем счетчик параметров
+		case strings.HasPrefix(value, CatchAllParamFlag):
+			// такой параметр должен быть самым последним в определении путей
+			if i != len(parts)-1 {
+				return nil, &PatternError{Pattern: pattern, Segment: i, Err: ErrCatchAllNotLast}
+			}
+			segment = Segment{Kind: SegmentCatchAll, Value: value[len(CatchAllParamFlag):]}
+			p.params |= 1 << 15 // взводим флаг динамического параметра
+		}
+		p.Segments[i] = segment
+		if segment.Kind == SegmentStatic || o.lax {
+			continue
+		}
+		var err error
+		switch name := segment.Value; {
+		case name == "":
+			// безымянный catch-all параметр используется для путей
+			// http.ServeMux, оканчивающихся на слеш
+			if segment.Kind != SegmentCatchAll || !o.serveMux {
+				err = ErrEmptyParamName
+			}
+		case !isStatic(name):
+			err = ErrBadParamName
+		case names[name]:
+			err = ErrDuplicateParam
+		}
+		if err != nil {
+			return nil, &PatternError{Pattern: pattern, Segment: i, Err: err}
+		}
+		if names == nil {
+			names = make(map[string]bool)
+		}
+		names[segment.Value] = true
+	}
+	return p, nil
+}
+
+// String returns the pattern in the syntax of the router.
+func (p *Pattern) String() string {
+	parts := make([]string, len(p.Segments))
+	for i, segment := range p.Segments {
+		parts[i] = segment.String()
+	}
+	return PathDelimeter + strings.Join(parts, PathDelimeter)
+}
+
+// Match checks the path against the pattern and returns the values of its
+// parameters. A named parameter matches a single path element, including the
+// empty one, the catch-all parameter matches the rest of the path.
+func (p *Pattern) Match(path string) (Params, bool) {
+	parts := splitter(path)
+	n := len(p.Segments)
+	if len(parts) < n || (len(parts) > n && (n == 0 || p.Segments[n-1].Kind != SegmentCatchAll)) {
+		return nil, false
+	}
+	params, mismatch, _ := match(p.Segments, parts, p.serveMux)
+	return params, mismatch < 0
+}
+
+// Build returns the path for the pattern with the values of parameters. The
+// values are escaped, for the catch-all parameter each path element is escaped
+// separately. Returns an error if the value of a parameter is not specified.
+func (p *Pattern) Build(params Params) (string, error) {
+	parts := make([]string, len(p.Segments))
+	for i, segment := range p.Segments {
+		if segment.Kind == SegmentStatic {
+			parts[i] = segment.Value
+			continue
+		}
+		value, ok := params.lookup(segment.Value)
+		if !ok {
+			return "", fmt.Errorf("%s: missing parameter %s", p, segment)
+		}
+		if segment.Kind == SegmentCatchAll {
+			elements := strings.Split(value, PathDelimeter)
+			for j, element := range elements {
+				elements[j] = url.PathEscape(element)
+			}
+			parts[i] = strings.Join(elements, PathDelimeter)
+		} else {
+			parts[i] = url.PathEscape(value)
+		}
+	}
+	return PathDelimeter + strings.Join(parts, PathDelimeter), nil
+}
+
+// match checks the elements of the path against the path elements of the
+// pattern and returns the values of parameters. The path must have the same
+// number of elements or more, if the pattern ends with the catch-all parameter.
+// If the path does not match, the index of the mismatched element and the
+// reason of the mismatch are returned, otherwise -1 and Matched.
+func match(segments []Segment, parts []string, serveMux bool) (Params, int, Reason) {
+	// сначала проверяем элементы пути, чтобы не выделять память под
+	// параметры неподходящих путей
+	var count int // количество параметров
+	for i, segment := range segments {
+		switch segment.Kind {
+		case SegmentParam:
+			// в синтаксисе http.ServeMux параметр не может быть пустым
+			if serveMux && parts[i] == "" {
+				return nil, i, EmptyParam
+			}
+			count++
+		case SegmentCatchAll:
+			count++
+		default:
+			if segment.Value != parts[i] {
+				return nil, i, SegmentMismatch
+			}
+		}
+	}
+	if count == 0 {
+		return nil, -1, Matched
+	}
+	params := make(Params, 0, count)
+	for i, segment := range segments {
+		switch segment.Kind {
+		case SegmentParam:
+			params = append(params, Param{Key: segment.Value, Value: parts[i]})
+		case SegmentCatchAll:
+			// добавляем весь оставшийся путь
+			params = append(params, Param{
+				Key:   segment.Value,
+				Value: strings.Join(parts[i:], PathDelimeter),
+			})
+		}
+	}
+	return params, -1, Matched
+}
@@ -1,19 +1,84 @@
 package router
 
+import "strings"
+
 // record describes information about the way in which there are parameters.
-type record struct {
-	params  uint16      // the number of parameters
-	parts   []string    // way disassembled into its component parts
-	handler interface{} // the request handler or something that is connected with it
+type record[T any] struct {
+	params   uint16    // the number of parameters
+	parts    []string  // way disassembled into its component parts
+	segments []Segment // the parsed path elements
+	handler  T         // the request handler or something that is connected with it
+	index    int       // the ordinal number of the route in order of adding
+	options            // additional settings of the route
+}
+
+// newRecord returns the record of the route for the parsed pattern.
+func newRecord[T any](pattern *Pattern, handler T, index int, o options) *record[T] {
+	return &record[T]{
+		params:   pattern.params,
+		parts:    pattern.parts,
+		segments: pattern.Segments,
+		handler:  handler,
+		index:    index,
+		options:  o,
+	}
+}
+
+// kind returns the kind of the route.
+func (r *record[T]) kind() MatchKind {
+	switch {
+	case r.params == 0:
+		return MatchStatic
+	case r.params>>15 == 1:
+		return MatchCatchAll
+	default:
+		return MatchParam
+	}
 }
 
-// records describes a list of parameters and supports sorting on the number of
-// parameters: the smaller the parameters the higher in the list. Account
-// dynamic parameter with the lowest priority, i.e. places them in the end of
-// the list.
-type records []*record
+// pattern returns the pattern of the route as a string.
+func (r *record[T]) pattern() string {
+	return PathDelimeter + strings.Join(r.parts, PathDelimeter)
+}
+
+// records describes a list of routes with the same number of path elements and
+// supports sorting them in order of checking:
+//
+//  1. routes with a catch-all parameter are always placed in the end of the
+//     list;
+//  2. routes with the higher priority set by WithPriority are placed higher;
+//  3. the smaller the parameters the higher in the list;
+//  4. the route with a static element earlier in the path is placed higher:
+//     /a/:x is checked before /:x/b.
+//
+// Otherwise the routes are checked in order of adding.
+type records[T any] []*record[T]
 
 // support methods for sorting.
-func (n records) Len() int           { return len(n) }
-func (n records) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
-func (n records) Less(i, j int) bool { return n[i].params < n[j].params }
+func (n records[T]) Len() int      { return len(n) }
+func (n records[T]) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
+func (n records[T]) Less(i, j int) bool {
+	a, b := n[i], n[j]
+	if a.params>>15 != b.params>>15 {
+		return a.params>>15 == 0 // динамический параметр всегда в конце
+	}
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	if a.params != b.params {
+		return a.params < b.params
+	}
+	// выигрывает путь, у которого статический элемент встречается раньше
+	for k := range a.parts {
+		if static := isStatic(a.parts[k]); static != isStatic(b.parts[k]) {
+			return static
+		}
+	}
+	return false
+}
+
+// isStatic returns true if the path element is not a parameter.
+func isStatic(part string) bool {
+	return !strings.HasPrefix(part, NamedParamFlag) &&
+		!strings.HasPrefix(part, CatchAllParamFlag)
+}
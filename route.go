@@ -0,0 +1,88 @@
+package router
+
+import "sort"
+
+// Route describes the registered route.
+type Route[T any] struct {
+	Method  string // the request method; empty for Table
+	Host    string // the host of the route; empty for all hosts and for Table
+	Pattern string // the pattern of the route in the syntax of the router
+	Handler T      // the handler of the route
+	Meta    Meta   // the metadata of the route
+}
+
+// Walk calls the function for each registered route in order of adding. If
+// the function returns an error, the walk is stopped and this error is
+// returned.
+func (r *Table[T]) Walk(fn func(Route[T]) error) error {
+	for _, record := range r.all() {
+		if err := fn(Route[T]{
+			Pattern: record.pattern(),
+			Handler: record.handler,
+			Meta:    record.meta,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Routes returns the list of all registered routes in order of adding.
+func (r *Table[T]) Routes() []Route[T] {
+	routes := make([]Route[T], 0, r.count)
+	r.Walk(func(route Route[T]) error {
+		routes = append(routes, route)
+		return nil
+	})
+	return routes
+}
+
+// all returns the records of all routes sorted in order of adding.
+func (r *Table[T]) all() []*record[T] {
+	list := make([]*record[T], 0, r.count)
+	for _, record := range r.static {
+		list = append(list, record)
+	}
+	for _, records := range r.fields {
+		list = append(list, records...)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].index < list[j].index })
+	return list
+}
+
+// ordered returns the records of all routes in order of their checking by
+// Lookup: static routes in order of adding first, then routes with parameters
+// from the longest to the shortest and, within the same length, in order of
+// priority.
+func (r *Table[T]) ordered() []*record[T] {
+	list := make([]*record[T], 0, r.count)
+	for _, record := range r.static {
+		list = append(list, record)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].index < list[j].index })
+	levels := make([]int, 0, len(r.fields))
+	for level := range r.fields {
+		levels = append(levels, int(level))
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+	for _, level := range levels {
+		list = append(list, r.fields[uint16(level)]...)
+	}
+	return list
+}
+
+// Ordered returns the list of all registered routes in order of their checking
+// by Lookup: static routes first, then routes with parameters from the longest
+// to the shortest and, within the same length, in order of priority.
+func (r *Table[T]) Ordered() []Route[T] {
+	records := r.ordered()
+	routes := make([]Route[T], len(records))
+	for i, record := range records {
+		routes[i] = Route[T]{
+			Pattern: record.pattern(),
+			Handler: record.handler,
+			Meta:    record.meta,
+		}
+	}
+	return routes
+}
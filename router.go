@@ -3,6 +3,7 @@ package router
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 )
@@ -23,18 +24,28 @@ func splitter(url string) []string {
 }
 
 // Paths describes the structure for quick selection handler for request path.
-// Supports both a static route and path parameters.
-type Paths struct {
+// Supports both a static route and path parameters. Handlers can be of any
+// type, so the result of Lookup has to be converted to the required type. If
+// all handlers are of the same type, it is better to use Table.
+type Paths = Table[interface{}]
+
+// Table describes the structure for quick selection handler of the type T for
+// request path. Supports both a static route and path parameters.
+type Table[T any] struct {
 	// хранилище статических путей, без параметров;
 	// в качестве ключа используется полный путь
-	static map[string]interface{}
+	static map[string]*record[T]
 	// хранит информацию о путях с параметрами;
 	// в качестве ключа используется общее количество элементов пути
-	fields map[uint16]records
+	fields map[uint16]records[T]
 	// максимальное количество частей пути во всех определениях
 	maxParts uint16
 	// позиция, в которой встречается самый ранний динамический параметр
 	catchAll uint16
+	// общее количество добавленных путей
+	count int
+	// учет совпадений путей, если включен
+	coverage *Coverage
 }
 
 // Add adds a new handler for the specified path. In the description of the way
@@ -43,9 +54,19 @@ type Paths struct {
 // latter case all the rest of the path will be included in this setting. A
 // starred parameter, if specified, must be the the last parameter of the path.
 //
-// Returns an error if the handler is not defined (nil), if the number of
-// elements of a URL path greater than 32768 or option with an asterisk is not
-// used in the last path element.
+// Returns an error if the handler is not defined (nil interface or function),
+// if the number of elements of a URL path greater than 32768 or option with an
+// asterisk is not used in the last path element. The names of parameters must
+// be non-empty and unique within the path and must not start with ':' or '*',
+// otherwise PatternError is returned. The option WithLaxSyntax disables this
+// check.
+//
+// Among the routes with the same number of path elements the routes with a
+// catch-all parameter are checked last. The others are checked in order of the
+// priority set by WithPriority, then the routes with fewer parameters first
+// and, with the same number of parameters, the route with a static element
+// earlier in the path: /a/:x is checked before /:x/b. Otherwise the routes are
+// checked in order of adding.
 //
 // ATTENTION! When adding a path is not verified by its uniqueness from the
 // point of view named parameters. Therefore, it is possible to add two
@@ -58,42 +79,66 @@ type Paths struct {
 // On the other hand, absolutely correctly fulfilled the following situation:
 // 	/:user/:name
 // 	/:user/test
-func (r *Paths) Add(url string, handler interface{}) error {
-	if handler == nil {
-		return errors.New("nil handler")
+//
+// Additional settings of the route, for example, metadata, can be specified
+// with options. With the option WithServeMux the path is specified in the
+// syntax of http.ServeMux.
+func (r *Table[T]) Add(url string, handler T, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
 	}
-	parts := splitter(url) // нормализуем путь и разбиваем его на части
-	// проверяем, что количество получившихся частей не превышает максимально
-	// поддерживаемое количество
-	level := uint16(len(parts)) // всего элементов пути
-	if level > (1<<15 - 1) {
-		return fmt.Errorf("path parts overflow: %d", len(parts))
-	}
-	// считаем количество параметров в определении пути
-	var params uint16
-	for i, value := range parts {
-		if strings.HasPrefix(value, NamedParamFlag) {
-			params++ // увеличиваем счетчик параметров
-		} else if strings.HasPrefix(value, CatchAllParamFlag) {
-			// такой параметр должен быть самым последним в определении путей
-			if uint16(i) != level-1 {
-				return errors.New("catch-all parameter must be last")
-			}
-			params |= 1 << 15 // взводим флаг динамического параметра
-			// запоминаем позицию самого раннего встреченного динамического
-			// параметра во всех добавленных путях
-			if r.catchAll == 0 || r.catchAll > level {
-				r.catchAll = level
-			}
+	if o.serveMux {
+		method, host, path := splitServeMux(url)
+		if method != "" {
+			return fmt.Errorf("method is not supported: %s", method)
+		}
+		if host != "" {
+			return fmt.Errorf("host is not supported: %s", host)
 		}
+		var err error
+		if url, err = convertServeMux(path); err != nil {
+			return err
+		}
+	}
+	return r.add(url, handler, o)
+}
+
+// add adds a new handler for the path in the syntax of the router with the
+// specified settings.
+func (r *Table[T]) add(url string, handler T, o options) error {
+	if isNil(handler) {
+		return errors.New("nil handler")
+	}
+	pattern, err := parsePattern(url, o)
+	if err != nil {
+		return err
+	}
+	rec := newRecord(pattern, handler, r.count, o)
+	r.count++
+	r.insert(rec)
+	if rec.params != 0 {
+		sort.Stable(r.fields[uint16(len(rec.parts))]) // сортируем по количеству параметров
+	}
+	return nil
+}
+
+// insert adds the record to the end of the list of routes with the same number
+// of path elements without sorting.
+func (r *Table[T]) insert(rec *record[T]) {
+	level := uint16(len(rec.parts)) // всего элементов пути
+	// запоминаем позицию самого раннего встреченного динамического
+	// параметра во всех добавленных путях
+	if rec.params>>15 == 1 && (r.catchAll == 0 || r.catchAll > level) {
+		r.catchAll = level
 	}
 	// если в пути нет параметров, то добавляем в статические обработчики
-	if params == 0 {
+	if rec.params == 0 {
 		if r.static == nil {
-			r.static = make(map[string]interface{})
+			r.static = make(map[string]*record[T])
 		}
-		r.static[strings.Join(parts, PathDelimeter)] = handler
-		return nil
+		r.static[strings.Join(rec.parts, PathDelimeter)] = rec
+		return
 	}
 	// запоминаем максимальное количество элементов пути во всех определениях
 	if r.maxParts < level {
@@ -101,36 +146,77 @@ func (r *Paths) Add(url string, handler interface{}) error {
 	}
 	// инициализируем динамические пути, если не сделали этого раньше
 	if r.fields == nil {
-		r.fields = make(map[uint16]records)
+		r.fields = make(map[uint16]records[T])
 	}
 	// добавляем в массив обработчиков с таким же количеством параметров
-	r.fields[level] = append(r.fields[level], &record{params, parts, handler})
-	sort.Stable(r.fields[level]) // сортируем по количеству параметров
-	return nil
+	r.fields[level] = append(r.fields[level], rec)
 }
 
 // Lookup returns the handler and the list of named parameters with their
-// values. If a suitable handler is found, it returns nil.
-func (r *Paths) Lookup(url string) (interface{}, Params) {
+// values. If a suitable handler is not found, it returns the zero value of T.
+func (r *Table[T]) Lookup(url string) (T, Params) {
+	record, params := r.lookup(url, nil)
+	if record == nil {
+		var zero T
+		return zero, nil
+	}
+	if r.coverage != nil {
+		r.coverage.hit(record.index)
+	}
+	return record.handler, params
+}
+
+// Match returns the description of the route selected for the path: its
+// handler, the values of named parameters, metadata, pattern and the kind of
+// the route. If a suitable route is not found, it returns false: the reason of
+// the miss can be obtained with Closest or Trace.
+func (r *Table[T]) Match(url string) (*Match[T], bool) {
+	record, params := r.lookup(url, nil)
+	if record == nil {
+		return nil, false
+	}
+	if r.coverage != nil {
+		r.coverage.hit(record.index)
+	}
+	return &Match[T]{
+		Handler: record.handler,
+		Params:  params,
+		Meta:    record.meta,
+		Pattern: record.pattern(),
+		Parts:   record.parts,
+		Index:   record.index,
+		Kind:    record.kind(),
+	}, true
+}
+
+// lookup returns the record of the route selected for the path and the values
+// of its named parameters. If the trace function is defined, it's called for
+// each checked record with the result of the check.
+func (r *Table[T]) lookup(url string, trace func(*record[T], Reason, int)) (*record[T], Params) {
 	parts := splitter(url) // нормализуем путь и разбиваем его на части
 	// сначала ищем среди статических путей; если статические пути не
 	// определены, то пропускаем проверку
 	if r.static != nil {
-		if handler, ok := r.static[strings.Join(parts, PathDelimeter)]; ok {
-			return handler, nil
+		if record, ok := r.static[strings.Join(parts, PathDelimeter)]; ok {
+			if trace != nil {
+				trace(record, Matched, -1)
+			}
+			return record, nil
 		}
 	}
 	// если пути с параметрами не определены, то на этом заканчиваем проверку
 	if r.fields == nil {
 		return nil, nil
 	}
-	length := uint16(len(parts)) // вычисляем количество элементов пути
+	// вычисляем количество элементов пути; путь запроса может быть длиннее
+	// максимально допустимого для определений, поэтому без приведения к uint16
+	length := len(parts)
 	// наши определения могут быть короче, если используются catchAll параметры,
 	// поэтому вычисляем с какой длины начинать
 	var total uint16
 	// если длина запроса больше максимальной длины определений, то нужно
 	// замахиваться на меньшее...
-	if length > r.maxParts {
+	if length > int(r.maxParts) {
 		// если нет динамических параметров, то ничего и не подойдет,
 		// потому что наш запрос явно длиннее
 		if r.catchAll == 0 {
@@ -138,7 +224,7 @@ func (r *Paths) Lookup(url string) (interface{}, Params) {
 		}
 		total = r.maxParts // начнем с максимального определения пути
 	} else {
-		total = length // наш запрос короче самого длинного определения
+		total = uint16(length) // наш запрос короче самого длинного определения
 	}
 	// запрашиваем список обработчиков для такого же количества элементов пути
 	for l := total; l > 0; l-- {
@@ -152,41 +238,30 @@ func (r *Paths) Lookup(url string) (interface{}, Params) {
 			// переходим к более короткому пути
 			continue
 		}
-	nextRecord:
 		// обработчики есть — перебираем все записи с ними
 		for _, record := range records {
 			// если наш путь длиннее обработчика, а он не содержит catchAll
 			// параметра, то он точно нам не подойдет
-			if l < length && record.params>>15 != 1 {
+			if int(l) < length && record.params>>15 != 1 {
+				if trace != nil {
+					trace(record, LengthMismatch, -1)
+				}
 				continue
 			}
-			// здесь мы будем собирать значения параметров к данному запросу
-			// если ранее они были не пустые от другого обработчика, то
-			// сбрасываем их
-			var params Params
-			// перебираем все части пути, заданные в обработчике
-			for i, part := range record.parts {
-				if strings.HasPrefix(part, NamedParamFlag) {
-					// это одиночный параметр
-					params = append(params, Param{
-						Key:   strings.TrimPrefix(part, NamedParamFlag),
-						Value: parts[i],
-					})
-				} else if strings.HasPrefix(part, CatchAllParamFlag) {
-					// это параметр, который заберет все
-					params = append(params, Param{
-						Key: strings.TrimPrefix(part, CatchAllParamFlag),
-						// добавляем весь оставшийся путь
-						Value: strings.Join(parts[i:], PathDelimeter),
-					})
-					break // больше ловить нечего — нашли
-				} else if part != parts[i] {
-					// статическая часть пути не совпадает с запрашиваемой
-					continue nextRecord // переходим к следующему обработчику
+			// сравниваем элементы пути и собираем значения параметров
+			params, mismatch, reason := match(record.segments, parts, record.serveMux)
+			if mismatch >= 0 {
+				// элемент пути не соответствует шаблону
+				if trace != nil {
+					trace(record, reason, mismatch)
 				}
+				continue // переходим к следующему обработчику
+			}
+			if trace != nil {
+				trace(record, Matched, -1)
 			}
 			// возвращаем найденный обработчик и заполненные параметры
-			return record.handler, params
+			return record, params
 		}
 	}
 	// сюда мы попадаем, если так ничего подходящего и не нашли
@@ -195,21 +270,59 @@ func (r *Paths) Lookup(url string) (interface{}, Params) {
 
 // Path returns a list of path elements associated with this processor.
 // If the handler is associated with multiple paths, return the first.
-func (r *Paths) Path(handler interface{}) []string {
+//
+// Handlers are compared by identity: functions, maps and slices are compared
+// by their address, values of other comparable types by the value. Handlers of
+// other types are never equal, use PathFunc for them.
+func (r *Table[T]) Path(handler T) []string {
+	return r.PathFunc(func(h T) bool { return same(h, handler) })
+}
+
+// PathFunc returns a list of path elements of the first route, for which
+// the function returns true.
+func (r *Table[T]) PathFunc(match func(handler T) bool) []string {
 	// перебираем статические пути
-	for url, h := range r.static {
-		if h == handler {
+	for url, record := range r.static {
+		if match(record.handler) {
 			return splitter(url)
 		}
 	}
 	// перебираем все пути с параметрами
 	for _, records := range r.fields {
 		for _, record := range records {
-			// сравниваем адреса методов
-			if handler == record.handler {
+			if match(record.handler) {
 				return record.parts
 			}
 		}
 	}
 	return nil // данный обработчик не зарегистрирован
 }
+
+// isNil returns true if the handler is nil interface or nil function.
+func isNil(handler interface{}) bool {
+	if handler == nil {
+		return true
+	}
+	value := reflect.ValueOf(handler)
+	return value.Kind() == reflect.Func && value.IsNil()
+}
+
+// same returns true if both handlers are the same. Functions, maps and slices
+// are compared by the address.
+func same(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return va.IsValid() == vb.IsValid()
+	}
+	if va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Func, reflect.Map:
+		return va.Pointer() == vb.Pointer()
+	case reflect.Slice:
+		return va.Pointer() == vb.Pointer() && va.Len() == vb.Len()
+	}
+	// значения, содержащие несравнимые типы, считаем разными
+	return va.Comparable() && vb.Comparable() && va.Equal(vb)
+}
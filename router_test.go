@@ -1,8 +1,12 @@
 package router
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -411,3 +415,876 @@ func testRoutersWithPaths(t *testing.T, routers []string, tests []TestStruct) {
 		}
 	}
 }
+
+func TestMeta(t *testing.T) {
+	var r Paths
+	if err := r.Add("/users", 0, WithMeta(Meta{"tags": []string{"users"}})); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add("/users/:id", 1,
+		WithMeta(Meta{"scope": "read", "deprecated": false}),
+		WithMeta(Meta{"deprecated": true})); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add("/files/*name", 2); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		URL string
+		Match[interface{}]
+	}{
+		{"/users", Match[interface{}]{
+			Handler: 0,
+			Meta:    Meta{"tags": []string{"users"}},
+			Pattern: "/users",
+			Parts:   []string{"users"},
+			Index:   0,
+			Kind:    MatchStatic,
+		}},
+		{"/users/1", Match[interface{}]{
+			Handler: 1,
+			Params:  Params{{"id", "1"}},
+			Meta:    Meta{"scope": "read", "deprecated": true},
+			Pattern: "/users/:id",
+			Parts:   []string{"users", ":id"},
+			Index:   1,
+			Kind:    MatchParam,
+		}},
+		{"/files/a/b", Match[interface{}]{
+			Handler: 2,
+			Params:  Params{{"name", "a/b"}},
+			Pattern: "/files/*name",
+			Parts:   []string{"files", "*name"},
+			Index:   2,
+			Kind:    MatchCatchAll,
+		}},
+	} {
+		match, ok := r.Match(test.URL)
+		if !ok {
+			t.Errorf("not matched: %v", test.URL)
+			continue
+		}
+		if !reflect.DeepEqual(*match, test.Match) {
+			t.Errorf("bad match %v: %v", test.URL, match)
+		}
+	}
+	if match, ok := r.Match("/missing"); ok || match != nil {
+		t.Errorf("bad missing match: %v", match)
+	}
+	for kind, name := range []string{"static", "param", "catch-all", "MatchKind(3)"} {
+		if MatchKind(kind).String() != name {
+			t.Errorf("bad kind name: %v", MatchKind(kind))
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	type handler func() string
+	var (
+		r        Table[handler]
+		list     = func() string { return "list" }
+		user     = func() string { return "user" }
+		missing  = func() string { return "missing" }
+		handlers = map[string]handler{"/users": list, "/users/:id": user}
+	)
+	for url, h := range handlers {
+		if err := r.Add(url, h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.Add("/test", nil) == nil {
+		t.Error("add nil handler")
+	}
+	h, params := r.Lookup("/users/1")
+	if h == nil || h() != "user" || params.Get("id") != "1" {
+		t.Errorf("bad lookup: %v", params)
+	}
+	if h, _ := r.Lookup("/missing"); h != nil {
+		t.Error("bad missing lookup")
+	}
+	for url, h := range handlers {
+		if path := "/" + strings.Join(r.Path(h), PathDelimeter); path != url {
+			t.Errorf("bad path: %v против %v", path, url)
+		}
+	}
+	if path := r.Path(missing); path != nil {
+		t.Errorf("bad missing path: %v", path)
+	}
+
+	for _, test := range []struct {
+		a, b interface{}
+		same bool
+	}{
+		{nil, nil, true},
+		{nil, 1, false},
+		{1, 1, true},
+		{1, "1", false},
+		{[]int{1}, []int{1}, false},
+		{Meta{}, Meta{}, false},
+		{struct{ f interface{} }{list}, struct{ f interface{} }{list}, false},
+	} {
+		if same(test.a, test.b) != test.same {
+			t.Errorf("bad same: %v, %v", test.a, test.b)
+		}
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	tests := []string{
+		"/users/:id",
+		"/users",
+		"/files/*name",
+		"/",
+		"/users/:id/posts",
+	}
+	var r Paths
+	for i, url := range tests {
+		if err := r.Add(url, i, WithMeta(Meta{"index": i})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	routes := r.Routes()
+	if len(routes) != len(tests) {
+		t.Fatalf("bad routes count: %v", len(routes))
+	}
+	for i, route := range routes {
+		if route.Pattern != tests[i] || route.Handler != i || route.Meta["index"] != i {
+			t.Errorf("bad route: %v", route)
+		}
+	}
+	errStop := errors.New("stop")
+	var count int
+	if err := r.Walk(func(route Route[interface{}]) error {
+		if count++; count == 2 {
+			return errStop
+		}
+		return nil
+	}); err != errStop || count != 2 {
+		t.Errorf("bad walk stop: %v, %v", err, count)
+	}
+}
+
+func TestWriteRoutes(t *testing.T) {
+	var r Paths
+	for i, url := range []string{
+		"/users",
+		"/users/:name",
+		"/users/:name/posts",
+		"/files/*path",
+		"/",
+	} {
+		if err := r.Add(url, i, WithMeta(Meta{"tag": "test", "index": i})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Add("/func", TestWriteRoutes); err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	if err := WriteRoutes(&buf, r.Routes()); err != nil {
+		t.Fatal(err)
+	}
+	table := "METHOD  PATTERN             HANDLER                                    META\n" +
+		"*       /users              0                                          index=0 tag=test\n" +
+		"*       /users/:name        1                                          index=1 tag=test\n" +
+		"*       /users/:name/posts  2                                          index=2 tag=test\n" +
+		"*       /files/*path        3                                          index=3 tag=test\n" +
+		"*       /                   4                                          index=4 tag=test\n" +
+		"*       /func               github.com/mdigger/router.TestWriteRoutes  \n"
+	if buf.String() != table {
+		t.Errorf("bad routes table:\n%s", buf.String())
+	}
+	buf.Reset()
+	if err := r.WriteTree(&buf); err != nil {
+		t.Fatal(err)
+	}
+	tree := "├── /users  [1 static] 0\n" +
+		"│   └── /:name  [5 param] 1\n" +
+		"│       └── /posts  [4 param] 2\n" +
+		"├── /  [2 static] 4\n" +
+		"├── /func  [3 static] github.com/mdigger/router.TestWriteRoutes\n" +
+		"└── /files\n" +
+		"    └── /*path  [6 catch-all] 3\n"
+	if buf.String() != tree {
+		t.Errorf("bad routes tree:\n%s", buf.String())
+	}
+	for _, test := range []struct {
+		Handler interface{}
+		Name    string
+	}{
+		{nil, "<nil>"},
+		{"test", `"test"`},
+		{true, "true"},
+		{MatchParam, "param"},
+		{Meta{}, "router.Meta"},
+	} {
+		if name := handlerName(test.Handler); name != test.Name {
+			t.Errorf("bad handler name: %v", name)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var r Paths
+	for i, url := range []string{
+		"/users",
+		"/users/:id",
+		"/users/:id/posts",
+		"/users/:id/:group",
+		"/files/*name",
+	} {
+		if err := r.Add(url, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, test := range []struct {
+		URL   string
+		Steps []string
+	}{
+		{"/users", []string{"/users: matched"}},
+		{"/users/1/posts", []string{"/users/:id/posts: matched"}},
+		{"/users/1/admins", []string{
+			"/users/:id/posts: static segment mismatch at 2",
+			"/users/:id/:group: matched",
+		}},
+		{"/files/1/2", []string{
+			"/users/:id/posts: static segment mismatch at 0",
+			"/users/:id/:group: static segment mismatch at 0",
+			"/users/:id: length mismatch",
+			"/files/*name: matched",
+		}},
+		{"/missing", nil},
+	} {
+		var steps []string
+		for _, step := range r.Trace(test.URL) {
+			steps = append(steps, step.String())
+		}
+		if !reflect.DeepEqual(steps, test.Steps) {
+			t.Errorf("bad trace %v:\n%v", test.URL, strings.Join(steps, "\n"))
+		}
+	}
+	var mux Paths
+	if err := mux.Add("/u/{id}", 1, WithServeMux()); err != nil {
+		t.Fatal(err)
+	}
+	if steps := mux.Trace("/u/"); len(steps) != 1 || steps[0].Reason != EmptyParam ||
+		steps[0].String() != "/u/:id: empty parameter at 1" {
+		t.Errorf("bad empty parameter trace: %v", steps)
+	}
+	if step, ok := mux.Closest("/u/"); !ok || step.Reason != EmptyParam || step.Segment != 1 {
+		t.Errorf("bad closest empty parameter: %v", step)
+	}
+
+	var closest Paths
+	for i, url := range []string{"/orgs/:org/repos", "/users/:id/posts", "/users/:id"} {
+		if err := closest.Add(url, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, test := range []struct {
+		URL, Step string
+	}{
+		{"/users/1/comments", "/users/:id/posts: static segment mismatch at 2"},
+		{"/teams/1/repos", "/orgs/:org/repos: static segment mismatch at 0"},
+		{"/users/1", ""},
+		{"/users", ""},
+	} {
+		step, ok := closest.Closest(test.URL)
+		if ok != (test.Step != "") || (ok && step.String() != test.Step) {
+			t.Errorf("bad closest route %v: %v", test.URL, step)
+		}
+	}
+	if Reason(4).String() != "Reason(4)" {
+		t.Error("bad reason name")
+	}
+}
+
+func TestPriority(t *testing.T) {
+	var r Table[string]
+	for _, route := range []struct {
+		URL      string
+		Priority int
+	}{
+		{"/:x/:y/:z", 0},
+		{"/:x/b/:z", 0},
+		{"/*path", 0},
+		{"/:x/:y/c", 0},
+		{"/a/:y/:z", 0},
+		{"/*files", 10},
+		{"/:x/y/z", 0},
+		{"/:x/:y/:z/:v", 5},
+		{"/:x/:y", -1},
+		{"/:x/:y/z", 0},
+		{"/:x/b", 0},
+		{"/:x/:y/d", 1},
+	} {
+		if err := r.Add(route.URL, route.URL, WithPriority(route.Priority)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// порядок проверки путей должен быть однозначным
+	var order []string
+	for _, route := range r.Ordered() {
+		order = append(order, route.Pattern)
+	}
+	if want := []string{
+		"/:x/:y/:z/:v",
+		"/:x/:y/d",
+		"/:x/y/z",
+		"/a/:y/:z",
+		"/:x/b/:z",
+		"/:x/:y/c",
+		"/:x/:y/z",
+		"/:x/:y/:z",
+		"/:x/b",
+		"/:x/:y",
+		"/*files",
+		"/*path",
+	}; !reflect.DeepEqual(order, want) {
+		t.Errorf("bad order:\n%v", strings.Join(order, "\n"))
+	}
+	for url, want := range map[string]string{
+		"/a/b/c": "/a/:y/:z",
+		"/x/b/c": "/:x/b/:z",
+		"/x/y/d": "/:x/:y/d",
+		"/x/y/z": "/:x/y/z",
+		"/x/b":   "/:x/b",
+		"/x/y":   "/:x/:y",
+		"/x":     "/*files",
+	} {
+		if handler, _ := r.Lookup(url); handler != want {
+			t.Errorf("bad handler for %v: %v", url, handler)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	registry := map[string]interface{}{"list": 0, "user": 1, "files": 2}
+	var r Paths
+	if err := r.Load(strings.NewReader(`[
+	{"pattern": "/users", "target": "list"},
+	{
+		"pattern": "/users/:id",
+		"target": "user",
+		"meta": {"scope": "read"}
+	},
+	{"pattern": "/files/*name", "target": "files"}
+]`), registry); err != nil {
+		t.Fatal(err)
+	}
+	var routes []string
+	for _, route := range r.Routes() {
+		routes = append(routes, fmt.Sprintf("%v %v %v", route.Pattern, route.Handler, route.Meta))
+	}
+	if want := []string{
+		"/users 0 map[]",
+		"/users/:id 1 map[scope:read]",
+		"/files/*name 2 map[]",
+	}; !reflect.DeepEqual(routes, want) {
+		t.Errorf("bad loaded routes:\n%v", strings.Join(routes, "\n"))
+	}
+
+	for _, test := range []struct {
+		Data, Error string
+	}{
+		{`[
+	{"pattern": "/users", "target": "list"},
+	{"pattern": "/files/*name/test", "target": "files"},
+	{"pattern": "", "target": "list"}, {"pattern": "/", "target": "unknown"},
+	{"pattern": "/", "method": "GET", "target": "list"}
+]`, "line 3: /files/*name/test: catch-all parameter must be last: *name at 1\n" +
+			"line 4: empty pattern\n" +
+			"line 4: unknown target: \"unknown\"\n" +
+			"line 5: method is not supported: GET"},
+		{"[\n\t{\"pattern\": \"/\", \"target\": 1}\n]", "line 2: json: cannot unmarshal number into Go struct field RouteEntry.target of type string"},
+		{"[\n\t{\"pattern\": \"/\", \"name\": \"list\"}\n]", "line 2: json: unknown field \"name\""},
+		{"[\n\t{\"pattern\": \"/\"\n\t\"target\": \"list\"}\n]", "line 3: invalid character '\"' after object key:value pair"},
+		{"{}", "line 1: route file must be an array"},
+		{"", "line 1: EOF"},
+		{"[{\"pattern\": \"/\", \"target\": \"list\"}", "line 1: unexpected end of JSON input"},
+		{"[\n\t{\"pattern\": \"/\", \"target\": \"list\"},\n\n\t{\n\t\t\"pattern\": \"/\",\n\n\t\t\"method\": 1\n\t}\n]",
+			"line 7: json: cannot unmarshal number into Go struct field RouteEntry.method of type string"},
+		{"[\n\t{\"pattern\": \"/\", \"target\": \"list\"}\n]\ntrailing", "line 4: invalid character 'a' in literal true (expecting 'u')"},
+		{"[]\n{}", "line 2: unexpected data after the route list"},
+	} {
+		var r Paths
+		err := r.Load(strings.NewReader(test.Data), registry)
+		if err == nil || err.Error() != test.Error {
+			t.Errorf("bad load error: %v", err)
+		}
+		var loadErr *LoadError
+		if !errors.As(err, &loadErr) || loadErr.Unwrap() == nil {
+			t.Errorf("bad load error type: %T", err)
+		}
+		if len(r.Routes()) != 0 {
+			t.Error("routes added with errors")
+		}
+	}
+}
+
+func TestSaveRestore(t *testing.T) {
+	tests := []string{
+		"/",
+		"/user/:id/post/:cid/:type",
+		"/user",
+		"/user/:id/:group",
+		"/user/:id/post",
+		"/user/:id",
+		"/files/*name",
+		"/user/:id/post/:cid",
+		"/admin/:id/post/test",
+		"/admin/:id/post/:cid",
+	}
+	var r Table[string]
+	for i, url := range tests {
+		if err := r.Add(url, fmt.Sprint("h", i), WithMeta(Meta{"index": i}),
+			WithPriority(i%3)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	key := func(handler string) (string, error) { return handler, nil }
+	value := func(key string) (string, error) { return key, nil }
+	var buf bytes.Buffer
+	if err := r.Save(&buf, key); err != nil {
+		t.Fatal(err)
+	}
+	var restored Table[string]
+	if err := restored.Add("/old", "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.Restore(bytes.NewReader(buf.Bytes()), value); err != nil {
+		t.Fatal(err)
+	}
+	order := func(r *Table[string]) (list []string) {
+		for _, record := range r.ordered() {
+			list = append(list, fmt.Sprint(record.pattern(), record.handler,
+				record.index, record.meta["index"], record.priority))
+		}
+		return list
+	}
+	if saved, restored := order(&r), order(&restored); !reflect.DeepEqual(saved, restored) {
+		t.Errorf("bad restored order:\n%v", strings.Join(restored, "\n"))
+	}
+	if h, _ := restored.Lookup("/old"); h != "" {
+		t.Error("old route is not removed")
+	}
+	for _, url := range append(tests, "/files/a/b", "/user/1/post/2/3", "/admin/1/post/test") {
+		h1, params1 := r.Lookup(url)
+		h2, params2 := restored.Lookup(url)
+		if h1 != h2 || !reflect.DeepEqual(params1, params2) {
+			t.Errorf("bad restored lookup %v: %v %v", url, h2, params2)
+		}
+	}
+	if err := restored.Add("/new", "new"); err != nil {
+		t.Fatal(err)
+	}
+	if match, _ := restored.Match("/new"); match.Index != len(tests) {
+		t.Errorf("bad index after restore: %v", match.Index)
+	}
+
+	errKey := errors.New("bad key")
+	if err := r.Save(&buf, func(string) (string, error) { return "", errKey }); !errors.Is(err, errKey) {
+		t.Errorf("bad save error: %v", err)
+	}
+	for _, data := range []string{
+		`{`,
+		`{"version": 2}`,
+		`{"version": 1, "routes": [{"pattern": "/*a/b"}]}`,
+		`{"version": 1, "routes": [{"pattern": "/a", "handler": "bad"}]}`,
+	} {
+		err := restored.Restore(strings.NewReader(data), func(key string) (string, error) {
+			if key == "bad" {
+				return "", errKey
+			}
+			return key, nil
+		})
+		if err == nil {
+			t.Errorf("bad data restored: %v", data)
+		}
+	}
+	var funcs Table[func()]
+	if err := funcs.Restore(strings.NewReader(`{"version": 1, "routes": [{"pattern": "/a"}]}`),
+		func(string) (func(), error) { return nil, nil }); err == nil {
+		t.Error("nil handler restored")
+	}
+}
+
+func TestServeMux(t *testing.T) {
+	var r Table[string]
+	for _, url := range []string{
+		"/users/{id}",
+		"/users/{id}/posts/{post}",
+		"/files/{path...}",
+		"/static/",
+		"/exact/{$}",
+		"/{$}",
+	} {
+		if err := r.Add(url, url, WithServeMux()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, test := range []struct {
+		URL     string
+		Handler string
+		Params  Params
+	}{
+		{"/users/1", "/users/{id}", Params{{"id", "1"}}},
+		{"/users/", "", nil},
+		{"/users/1/posts/2", "/users/{id}/posts/{post}", Params{{"id", "1"}, {"post", "2"}}},
+		{"/files/a/b", "/files/{path...}", Params{{"path", "a/b"}}},
+		{"/static/", "/static/", Params{{"", ""}}},
+		{"/static/css/main.css", "/static/", Params{{"", "css/main.css"}}},
+		{"/exact/", "/exact/{$}", nil},
+		{"/exact/more", "", nil},
+		{"/", "/{$}", nil},
+		{"/other", "", nil},
+	} {
+		handler, params := r.Lookup(test.URL)
+		if handler != test.Handler || !reflect.DeepEqual(params, test.Params) {
+			t.Errorf("bad lookup %v: %q %v", test.URL, handler, params)
+		}
+	}
+	if pattern := r.Routes()[0].Pattern; pattern != "/users/:id" {
+		t.Errorf("bad converted pattern: %v", pattern)
+	}
+	for _, url := range []string{
+		"GET /users",
+		"example.com/users",
+		"users",
+		"/users/{id...}/posts",
+		"/users/{}",
+		"/users/id{id}",
+		"/users/{$}/posts",
+		"/users/:id",
+	} {
+		if err := r.Add(url, url, WithServeMux()); err == nil {
+			t.Errorf("bad pattern added: %v", url)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	var r Table[int]
+	coverage := r.Coverage()
+	for i, url := range []string{"/users", "/users/:id", "/files/*name"} {
+		if err := r.Add(url, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.Lookup("/users/1")
+	r.Lookup("/users/2")
+	r.Match("/users")
+	r.Lookup("/missing")
+	r.Trace("/files/a")
+	if r.Coverage() != coverage {
+		t.Error("coverage recorder is changed")
+	}
+	report := coverage.Report()
+	if report.Total != 3 || report.Covered != 2 || report.Routes[1].Hits != 2 {
+		t.Errorf("bad coverage report: %+v", report)
+	}
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if text := "routes covered: 2 of 3 (66.7%)\nnot covered:\n\t* /files/*name\n"; buf.String() != text {
+		t.Errorf("bad coverage text:\n%s", buf.String())
+	}
+	buf.Reset()
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded CoverageReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Covered != 2 || len(decoded.Routes) != 3 || decoded.Routes[2].Pattern != "/files/*name" {
+		t.Errorf("bad coverage json:\n%s", buf.String())
+	}
+	coverage.Reset()
+	if report := coverage.Report(); report.Covered != 0 || len(report.Uncovered()) != 3 {
+		t.Errorf("bad coverage after reset: %+v", report)
+	}
+	if percent := new(CoverageReport).Percent(); percent != 100 {
+		t.Errorf("bad empty coverage percent: %v", percent)
+	}
+}
+
+func TestDiffRoutes(t *testing.T) {
+	var old Table[string]
+	for _, url := range []string{
+		"/users",
+		"/users/:id",
+		"/users/:id/posts",
+		"/files/:name",
+		"/docs/:page",
+		"/admin",
+	} {
+		if err := old.Add(url, url, WithMeta(Meta{"version": 1})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// сравниваем с сохраненной таблицей
+	var buf bytes.Buffer
+	if err := old.Save(&buf, func(handler string) (string, error) { return handler, nil }); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := ReadSavedRoutes(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(saved[0], Route[string]{Pattern: "/users", Handler: "/users",
+		Meta: Meta{"version": float64(1)}}) || len(saved) != 6 {
+		t.Fatalf("bad saved routes: %v", saved)
+	}
+	if diff, err := DiffRoutes(saved, old.Routes()); err != nil || len(diff) != 0 {
+		t.Errorf("unexpected changes:\n%v %v", diff, err)
+	}
+
+	var current Table[int]
+	for i, route := range []struct {
+		URL  string
+		Meta Meta
+	}{
+		{"/users", Meta{"version": 1}},
+		{"/users/:user", Meta{"version": 1}},
+		{"/users/:id/posts", Meta{"version": 2}},
+		{"/files/*path", Meta{"version": 1}},
+		{"/status", nil},
+	} {
+		if err := current.Add(route.URL, i, WithMeta(route.Meta)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	diff, err := DiffRoutes(saved, current.Routes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- /files/:name\n" +
+		"- /docs/:page (breaking)\n" +
+		"- /admin (breaking)\n" +
+		"~ /users/:id: parameter :id renamed to :user\n" +
+		"~ /users/:id/posts: meta changed\n" +
+		"+ /files/*path\n" +
+		"+ /status"; diff.String() != want {
+		t.Errorf("bad diff:\n%v", diff)
+	}
+	if !diff.Breaking() || diff[0].Kind != RouteRemoved || diff[3].Kind.String() != "modified" {
+		t.Errorf("bad changes: %#v", diff)
+	}
+
+	// изменение метода
+	oldRoutes := []Route[string]{
+		{Method: "GET", Pattern: "/users/:id"},
+		{Method: "POST", Pattern: "/users"},
+	}
+	newRoutes := []Route[string]{
+		{Method: "GET", Pattern: "/users/:id"},
+		{Method: "PUT", Pattern: "/users"},
+		{Method: "POST", Pattern: "/users/*path"},
+	}
+	if diff, _ := DiffRoutes(oldRoutes, newRoutes); diff.String() !=
+		"~ POST /users: method POST changed to PUT (breaking)\n+ POST /users/*path" {
+		t.Errorf("bad method diff:\n%v", diff)
+	}
+	entries := []RouteEntry{{Method: "GET", Pattern: "/users/:id", Target: "user"}}
+	if diff, err := DiffRoutes(EntryRoutes(entries), newRoutes[:1]); err != nil || len(diff) != 0 {
+		t.Errorf("unexpected entry changes:\n%v %v", diff, err)
+	}
+
+	// пути с нестрогим синтаксисом и для отдельных хостов
+	oldRoutes = []Route[string]{
+		{Method: "GET", Pattern: "/a/:id/:id"},
+		{Method: "GET", Host: "example.com", Pattern: "/items/:id"},
+		{Method: "GET", Host: "example.com", Pattern: "/files/:name"},
+	}
+	newRoutes = []Route[string]{
+		{Method: "GET", Pattern: "/a/:id/:id"},
+		{Method: "GET", Host: "example.com", Pattern: "/items/:item"},
+		{Method: "GET", Pattern: "/files/*path"},
+	}
+	if diff, err := DiffRoutes(oldRoutes, newRoutes); err != nil || diff.String() !=
+		"- GET example.com/files/:name\n"+
+			"~ GET example.com/items/:id: parameter :id renamed to :item\n"+
+			"+ GET /files/*path" {
+		t.Errorf("bad lax diff:\n%v %v", diff, err)
+	}
+	if _, err := DiffRoutes(oldRoutes, []Route[string]{{Pattern: "/files/*path/info"}}); err == nil {
+		t.Error("bad pattern compared")
+	}
+	if ChangeKind(5).String() != "ChangeKind(5)" {
+		t.Error("bad change kind name")
+	}
+}
+
+func TestLint(t *testing.T) {
+	var table Table[int]
+	for i, url := range []string{
+		"/users/:id",
+		"/users/:name/posts",
+		"/users/:id/:x",
+		"/files/*",
+		"/a/:id/:id",
+		"/docs/",
+		"/:page/posts",
+		"/items/:item",
+	} {
+		if err := table.Add(url, i, WithLaxSyntax()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.Add("/items/{id}", 10, WithServeMux()); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Add("/blog/", 11, WithServeMux()); err != nil {
+		t.Fatal(err)
+	}
+	issues := table.Lint()
+	var lines []string
+	for _, issue := range issues {
+		lines = append(lines, fmt.Sprintf("%v %d %v", issue.Kind, issue.Segment, issue))
+	}
+	if want := []string{
+		"param name 1 /users/:name/posts: parameter :name differs from :id in /users/:id",
+		"empty param 1 /files/*: parameter * without name",
+		"duplicate param 2 /a/:id/:id: duplicate parameter :id",
+		"trailing slash -1 /docs/: ends with a slash unlike 9 other routes",
+		"param name 1 /items/:id: parameter :id differs from :item in /items/:item",
+		"shadowed -1 /items/:id: shadowed by /items/:item",
+	}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("bad issues:\n%v", strings.Join(lines, "\n"))
+	}
+	if LintKind(9).String() != "LintKind(9)" {
+		t.Error("bad lint kind name")
+	}
+}
+
+func TestPatternSyntax(t *testing.T) {
+	for _, test := range []struct {
+		URL     string
+		Segment int
+		Err     error
+	}{
+		{"/users/:", 1, ErrEmptyParamName},
+		{"/a/:id/:id", 2, ErrDuplicateParam},
+		{"/a/:id/*id", 2, ErrDuplicateParam},
+		{"/x/*", 1, ErrEmptyParamName},
+		{"/::x", 0, ErrBadParamName},
+		{"/a/:*x", 1, ErrBadParamName},
+		{"/files/*name/info", 1, ErrCatchAllNotLast},
+	} {
+		var r Table[int]
+		err := r.Add(test.URL, 1)
+		var patternErr *PatternError
+		if !errors.As(err, &patternErr) || !errors.Is(err, test.Err) ||
+			patternErr.Pattern != test.URL || patternErr.Segment != test.Segment {
+			t.Errorf("bad error for %v: %v", test.URL, err)
+			continue
+		}
+		// без проверки имен добавляются все пути, кроме catch-all не в конце
+		err = r.Add(test.URL, 1, WithLaxSyntax())
+		if (err == nil) == (test.Err == ErrCatchAllNotLast) {
+			t.Errorf("bad lax error for %v: %v", test.URL, err)
+		}
+	}
+	err := new(Table[int]).Add("/a/:id/:id", 1)
+	if err.Error() != "/a/:id/:id: duplicate parameter name: :id at 2" {
+		t.Errorf("bad error message: %v", err)
+	}
+
+	var r Table[int]
+	if err := r.Add("/static/", 1, WithServeMux()); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add("/lax/:/:", 2, WithLaxSyntax()); err != nil {
+		t.Fatal(err)
+	}
+	// настройки синтаксиса сохраняются вместе с путями
+	var buf bytes.Buffer
+	if err := r.Save(&buf, func(handler int) (string, error) { return fmt.Sprint(handler), nil }); err != nil {
+		t.Fatal(err)
+	}
+	var restored Table[int]
+	if err := restored.Restore(&buf, strconv.Atoi); err != nil {
+		t.Fatal(err)
+	}
+	if h, params := restored.Lookup("/lax/a/b"); h != 2 || params.Get("") != "a" {
+		t.Errorf("bad lax route: %v %v", h, params)
+	}
+	if h, _ := restored.Lookup("/static/a/b"); h != 1 {
+		t.Errorf("bad ServeMux route: %v", h)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	pattern, err := ParsePattern("/repos/:owner/:repo/files/*path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, segment := range pattern.Segments {
+		kinds = append(kinds, fmt.Sprintf("%v %s", segment.Kind, segment.Value))
+	}
+	if want := []string{"static repos", "param owner", "param repo", "static files",
+		"catch-all path"}; !reflect.DeepEqual(kinds, want) {
+		t.Errorf("bad segments: %q", kinds)
+	}
+	if s := pattern.String(); s != "/repos/:owner/:repo/files/*path" {
+		t.Errorf("bad pattern string: %v", s)
+	}
+
+	for _, test := range []struct {
+		URL    string
+		Params Params
+		Ok     bool
+	}{
+		{"/repos/mdigger/router/files/a/b.go", Params{{"owner", "mdigger"},
+			{"repo", "router"}, {"path", "a/b.go"}}, true},
+		{"/repos/mdigger/router/files/", Params{{"owner", "mdigger"},
+			{"repo", "router"}, {"path", ""}}, true},
+		{"/repos/mdigger/router/files", nil, false},
+		{"/repos/mdigger/router/tree/a", nil, false},
+	} {
+		params, ok := pattern.Match(test.URL)
+		if ok != test.Ok || !reflect.DeepEqual(params, test.Params) {
+			t.Errorf("bad match %v: %v %v", test.URL, params, ok)
+		}
+	}
+	// сопоставление совпадает с выбором пути в таблице
+	var table Table[int]
+	table.Add(pattern.String(), 1)
+	if _, params := table.Lookup("/repos/a/b/files/c"); !reflect.DeepEqual(params,
+		Params{{"owner", "a"}, {"repo", "b"}, {"path", "c"}}) {
+		t.Errorf("bad lookup params: %v", params)
+	}
+
+	url, err := pattern.Build(Params{{"owner", "md igger"}, {"repo", "router"},
+		{"path", "a b/c?.go"}})
+	if err != nil || url != "/repos/md%20igger/router/files/a%20b/c%3F.go" {
+		t.Errorf("bad url: %v %v", url, err)
+	}
+	if _, err := pattern.Build(Params{{"owner", "mdigger"}}); err == nil ||
+		err.Error() != "/repos/:owner/:repo/files/*path: missing parameter :repo" {
+		t.Errorf("bad build error: %v", err)
+	}
+
+	static, err := ParsePattern("/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params, ok := static.Match("/"); !ok || params != nil {
+		t.Errorf("bad static match: %v %v", params, ok)
+	}
+	if _, ok := static.Match("/a"); ok {
+		t.Error("bad static match")
+	}
+	if _, ok := new(Pattern).Match("/"); ok {
+		t.Error("empty pattern matched")
+	}
+	if _, err := ParsePattern("/a/:id/:id"); !errors.Is(err, ErrDuplicateParam) {
+		t.Errorf("bad parse error: %v", err)
+	}
+	if SegmentKind(5).String() != "SegmentKind(5)" {
+		t.Error("bad segment kind name")
+	}
+}
@@ -0,0 +1,134 @@
+// Package routertest contains helpers for testing route tables of the router
+// package: checks of the route selected for the path and table-driven runners,
+// which print readable differences of parameters on failure.
+//
+//	table := routertest.New(t, "/users", "/users/:id")
+//	routertest.Run(t, table, []routertest.Case[int]{
+//		{URL: "/users", Handler: 0},
+//		{URL: "/users/42", Handler: 1, Params: router.Params{{Key: "id", Value: "42"}}},
+//		{URL: "/missing", NoMatch: true},
+//	})
+package routertest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mdigger/router"
+)
+
+// New returns the table with the routes for the patterns. The handler of each
+// route is its index in the list of patterns. If a pattern can't be added, the
+// test is stopped.
+func New(t testing.TB, patterns ...string) *router.Table[int] {
+	t.Helper()
+	table := new(router.Table[int])
+	for i, pattern := range patterns {
+		if err := table.Add(pattern, i); err != nil {
+			t.Fatalf("%s: %v", pattern, err)
+		}
+	}
+	return table
+}
+
+// AssertMatch checks that the route with the handler want is selected for the
+// path and its parameters are equal to params. Functions as handlers are
+// compared by the address, other values with reflect.DeepEqual. Returns false
+// and reports an error if the check fails.
+func AssertMatch[T any](t testing.TB, table *router.Table[T], url string, want T, params router.Params) bool {
+	t.Helper()
+	match, ok := table.Match(url)
+	if !ok {
+		t.Errorf("%s: no route matched, want handler %v", url, want)
+		return false
+	}
+	if !equal(match.Handler, want) {
+		t.Errorf("%s: matched route %s with handler %v, want handler %v",
+			url, match.Pattern, match.Handler, want)
+		return false
+	}
+	if !reflect.DeepEqual(match.Params, params) &&
+		(len(match.Params) != 0 || len(params) != 0) {
+		t.Errorf("%s: route %s params mismatch (-want +got):\n%s",
+			url, match.Pattern, DiffParams(params, match.Params))
+		return false
+	}
+	return true
+}
+
+// AssertNoMatch checks that no route is selected for the path. Returns false
+// and reports an error if the check fails.
+func AssertNoMatch[T any](t testing.TB, table *router.Table[T], url string) bool {
+	t.Helper()
+	if match, ok := table.Match(url); ok {
+		t.Errorf("%s: unexpected route %s with handler %v", url, match.Pattern, match.Handler)
+		return false
+	}
+	return true
+}
+
+// Case describes a single check of the route table.
+type Case[T any] struct {
+	Name    string        // the name of the subtest; the path if empty
+	URL     string        // the checked path
+	Handler T             // the handler of the expected route
+	Params  router.Params // the expected parameters
+	NoMatch bool          // no route must be selected for the path
+}
+
+// Run checks all cases, each one in its own subtest.
+func Run[T any](t *testing.T, table *router.Table[T], cases []Case[T]) {
+	t.Helper()
+	for _, test := range cases {
+		test := test
+		name := test.Name
+		if name == "" {
+			name = test.URL
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+			if test.NoMatch {
+				AssertNoMatch(t, table, test.URL)
+			} else {
+				AssertMatch(t, table, test.URL, test.Handler, test.Params)
+			}
+		})
+	}
+}
+
+// DiffParams returns the differences between the lists of parameters, one
+// parameter per line: the equal parameters are prefixed with spaces, the
+// expected ones with "-" and the actual ones with "+". Values are quoted, so
+// empty values and spaces are visible.
+func DiffParams(want, got router.Params) string {
+	var lines []string
+	for i := 0; i < len(want) || i < len(got); i++ {
+		switch {
+		case i < len(want) && i < len(got) && want[i] == got[i]:
+			lines = append(lines, "  "+format(want[i]))
+			continue
+		case i < len(want):
+			lines = append(lines, "- "+format(want[i]))
+		}
+		if i < len(got) {
+			lines = append(lines, "+ "+format(got[i]))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// format returns the parameter as a string for the list of differences.
+func format(param router.Param) string {
+	return fmt.Sprintf("%s: %q", param.Key, param.Value)
+}
+
+// equal returns true if the handlers are the same.
+func equal(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && va.Kind() == reflect.Func && vb.Kind() == reflect.Func {
+		return va.Type() == vb.Type() && va.Pointer() == vb.Pointer()
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,86 @@
+package routertest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mdigger/router"
+)
+
+// recorder saves the errors of checks instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssert(t *testing.T) {
+	table := New(t, "/users", "/users/:id", "/files/*path")
+	Run(t, table, []Case[int]{
+		{URL: "/users", Handler: 0},
+		{URL: "/users/42", Handler: 1, Params: router.Params{{Key: "id", Value: "42"}}},
+		{Name: "catch-all", URL: "/files/a/b", Handler: 2,
+			Params: router.Params{{Key: "path", Value: "a/b"}}},
+		{URL: "/missing", NoMatch: true},
+	})
+
+	for _, test := range []struct {
+		Check func(t testing.TB) bool
+		Error string
+	}{
+		{func(t testing.TB) bool { return AssertMatch(t, table, "/missing", 0, nil) },
+			"/missing: no route matched, want handler 0"},
+		{func(t testing.TB) bool { return AssertMatch(t, table, "/users/42", 0, nil) },
+			"/users/42: matched route /users/:id with handler 1, want handler 0"},
+		{func(t testing.TB) bool {
+			return AssertMatch(t, table, "/users/42", 1, router.Params{{Key: "id", Value: "4 2"}})
+		}, "/users/42: route /users/:id params mismatch (-want +got):\n" +
+			"- id: \"4 2\"\n" +
+			"+ id: \"42\""},
+		{func(t testing.TB) bool { return AssertNoMatch(t, table, "/users") },
+			"/users: unexpected route /users with handler 0"},
+	} {
+		r := new(recorder)
+		if test.Check(r) {
+			t.Errorf("check passed: %v", test.Error)
+		}
+		if len(r.errors) != 1 || r.errors[0] != test.Error {
+			t.Errorf("bad errors:\n%v", strings.Join(r.errors, "\n"))
+		}
+	}
+}
+
+func TestAssertFunc(t *testing.T) {
+	first := func() {}
+	second := func() {}
+	var table router.Table[func()]
+	if err := table.Add("/first", first); err != nil {
+		t.Fatal(err)
+	}
+	AssertMatch(t, &table, "/first", first, nil)
+	r := new(recorder)
+	if AssertMatch(r, &table, "/first", second, nil) {
+		t.Error("different functions are equal")
+	}
+}
+
+func TestDiffParams(t *testing.T) {
+	diff := DiffParams(
+		router.Params{{Key: "owner", Value: "mdigger"}, {Key: "repo", Value: "router"},
+			{Key: "number", Value: "1"}},
+		router.Params{{Key: "owner", Value: "mdigger"}, {Key: "repo", Value: ""}},
+	)
+	want := "  owner: \"mdigger\"\n" +
+		"- repo: \"router\"\n" +
+		"+ repo: \"\"\n" +
+		"- number: \"1\""
+	if diff != want {
+		t.Errorf("bad diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// saveVersion is the version of the format of the saved route table.
+const saveVersion = 1
+
+// savedTable describes the saved route table.
+type savedTable struct {
+	Version int          `json:"version"`
+	Routes  []savedRoute `json:"routes"`
+}
+
+// savedRoute describes the saved route.
+type savedRoute struct {
+	Pattern  string `json:"pattern"`
+	Handler  string `json:"handler"`
+	Index    int    `json:"index"`
+	Priority int    `json:"priority,omitempty"`
+	ServeMux bool   `json:"serveMux,omitempty"`
+	Lax      bool   `json:"lax,omitempty"`
+	Meta     Meta   `json:"meta,omitempty"`
+}
+
+// Save writes the compiled route table in JSON format: the patterns of routes
+// in order of their checking, their metadata, settings and the keys of
+// handlers. The key of the handler is returned by the specified function. The
+// routes can be restored later by Restore without sorting them again.
+//
+// The metadata is saved in JSON format too, so after restoring the numbers
+// become float64 and the structures become maps.
+func (r *Table[T]) Save(w io.Writer, key func(handler T) (string, error)) error {
+	records := r.ordered()
+	saved := savedTable{
+		Version: saveVersion,
+		Routes:  make([]savedRoute, len(records)),
+	}
+	for i, record := range records {
+		name, err := key(record.handler)
+		if err != nil {
+			return fmt.Errorf("%s: %w", record.pattern(), err)
+		}
+		saved.Routes[i] = savedRoute{
+			Pattern:  record.pattern(),
+			Handler:  name,
+			Index:    record.index,
+			Priority: record.priority,
+			ServeMux: record.serveMux,
+			Lax:      record.lax,
+			Meta:     record.meta,
+		}
+	}
+	return json.NewEncoder(w).Encode(saved)
+}
+
+// Restore reads the route table saved by Save and replaces with it all the
+// routes of the table. The handlers are obtained by their keys with the
+// specified function.
+func (r *Table[T]) Restore(rd io.Reader, value func(key string) (T, error)) error {
+	var saved savedTable
+	if err := json.NewDecoder(rd).Decode(&saved); err != nil {
+		return err
+	}
+	if saved.Version != saveVersion {
+		return fmt.Errorf("unsupported route table version: %d", saved.Version)
+	}
+	var table Table[T]
+	for _, route := range saved.Routes {
+		o := options{meta: route.Meta, priority: route.Priority,
+			serveMux: route.ServeMux, lax: route.Lax}
+		pattern, err := parsePattern(route.Pattern, o)
+		if err != nil {
+			return err
+		}
+		handler, err := value(route.Handler)
+		if err != nil {
+			return fmt.Errorf("%s: %w", route.Pattern, err)
+		}
+		if isNil(handler) {
+			return fmt.Errorf("%s: nil handler", route.Pattern)
+		}
+		table.insert(newRecord(pattern, handler, route.Index, o))
+		if table.count <= route.Index {
+			table.count = route.Index + 1
+		}
+	}
+	table.coverage = r.coverage // учет совпадений сохраняется
+	*r = table
+	return nil
+}
@@ -0,0 +1,88 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithServeMux allows to use the pattern syntax of http.ServeMux from Go 1.22
+// instead of the own syntax of the router:
+//
+//	[METHOD ][HOST]/[PATH]
+//
+// The wildcard {name} matches a single non-empty path element and is converted
+// to the named parameter :name, the wildcard {name...} matches the rest of the
+// path and is converted to the catch-all parameter *name. A pattern ending with
+// a slash matches all paths with this prefix, as with http.ServeMux: /files/
+// is converted to /files/* with the catch-all parameter without a name. The
+// special wildcard {$} at the end of the pattern disables this behavior and
+// matches only the path with the trailing slash. Routes, Walk and Match return
+// the converted pattern.
+//
+// The method and the host are supported only by Router, Table.Add returns an
+// error for them. Unlike http.ServeMux, the routes with the method GET do not
+// match HEAD requests, the path without a trailing slash is not redirected to
+// the route with it and the precedence of routes is determined by the rules of
+// this router.
+func WithServeMux() Option {
+	return func(o *options) {
+		o.serveMux = true
+	}
+}
+
+// splitServeMux splits the pattern in the syntax of http.ServeMux into the
+// method, the host and the path.
+func splitServeMux(pattern string) (method, host, path string) {
+	path = strings.TrimLeft(pattern, " \t")
+	if i := strings.IndexAny(path, " \t"); i >= 0 {
+		method, path = path[:i], strings.TrimLeft(path[i:], " \t")
+	}
+	if i := strings.Index(path, "/"); i > 0 {
+		host, path = path[:i], path[i:]
+	}
+	return method, host, path
+}
+
+// convertServeMux converts the path in the syntax of http.ServeMux into the
+// syntax of the router.
+func convertServeMux(path string) (string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("%s: path must start with a slash", path)
+	}
+	parts := strings.Split(path[1:], "/")
+	last := len(parts) - 1
+	for i, part := range parts {
+		switch {
+		case part == "{$}":
+			// {$} допустим только в конце пути после слеша
+			if i != last {
+				return "", fmt.Errorf("%s: {$} must be at the end after a slash", path)
+			}
+			parts[i] = ""
+			return PathDelimeter + strings.Join(parts, PathDelimeter), nil
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			name := part[1 : len(part)-1]
+			flag := NamedParamFlag
+			if strings.HasSuffix(name, "...") {
+				if i != last {
+					return "", fmt.Errorf("%s: %s must be last", path, part)
+				}
+				name, flag = strings.TrimSuffix(name, "..."), CatchAllParamFlag
+			}
+			if name == "" || strings.ContainsAny(name, "{}") {
+				return "", fmt.Errorf("%s: bad wildcard %s", path, part)
+			}
+			parts[i] = flag + name
+		case strings.ContainsAny(part, "{}"):
+			return "", fmt.Errorf("%s: wildcard must be a full path element: %s", path, part)
+		case strings.HasPrefix(part, NamedParamFlag), strings.HasPrefix(part, CatchAllParamFlag):
+			// такой элемент пути был бы воспринят как параметр
+			return "", fmt.Errorf("%s: element %s is not supported", path, part)
+		}
+	}
+	// путь со слешем в конце соответствует всем вложенным путям
+	if parts[last] == "" {
+		parts[last] = CatchAllParamFlag
+	}
+	return PathDelimeter + strings.Join(parts, PathDelimeter), nil
+}
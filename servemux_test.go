@@ -0,0 +1,226 @@
+// Сравнение ведется с http.ServeMux из Go 1.22, который без go.mod по
+// умолчанию отключен.
+
+//go:debug httpmuxgo121=0
+
+package router
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// muxResult describes the result of processing the request by the router or
+// by http.ServeMux.
+type muxResult struct {
+	Status   int    // статус ответа
+	Pattern  string // шаблон выбранного пути
+	Params   string // значения параметров
+	Location string // адрес перенаправления
+	Allow    string // список разрешенных методов
+}
+
+// disagreement describes the difference between the results of the router and
+// http.ServeMux for the same request.
+type disagreement struct {
+	Method, Path string
+	Kind         string // match, params, slash или status
+	Mux, Router  muxResult
+}
+
+func (d disagreement) String() string {
+	return fmt.Sprintf("%s %s: %s: mux %+v, router %+v", d.Method, d.Path, d.Kind, d.Mux, d.Router)
+}
+
+// diffServeMux registers the routes in the router and in http.ServeMux, sends
+// them the requests and returns the list of disagreements. The routes are
+// specified in the syntax of http.ServeMux with the method.
+func diffServeMux(t *testing.T, routes []string, requests [][2]string) []disagreement {
+	t.Helper()
+	// обработчик возвращает шаблон пути и значения его параметров
+	handler := func(route string) http.HandlerFunc {
+		var names []string
+		for _, part := range strings.Split(route, "/") {
+			if strings.HasPrefix(part, "{") && part != "{$}" {
+				names = append(names, strings.TrimSuffix(strings.Trim(part, "{}"), "..."))
+			}
+		}
+		return func(w http.ResponseWriter, req *http.Request) {
+			values := make([]string, len(names))
+			for i, name := range names {
+				values[i] = name + "=" + req.PathValue(name)
+			}
+			w.Header().Set("X-Pattern", route)
+			w.Header().Set("X-Params", strings.Join(values, " "))
+		}
+	}
+	mux := http.NewServeMux()
+	var r Router
+	for _, route := range routes {
+		mux.Handle(route, handler(route))
+		if _, err := r.Handle("", route, handler(route), WithServeMux()); err != nil {
+			t.Fatalf("%v: %v", route, err)
+		}
+	}
+	serve := func(h http.Handler, method, path string) muxResult {
+		req := httptest.NewRequest(method, "/", nil)
+		req.URL.Path = path
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		// http.ServeMux всегда добавляет HEAD к GET
+		var allow []string
+		for _, method := range strings.Split(w.Header().Get("Allow"), ", ") {
+			if method != "" && method != "HEAD" {
+				allow = append(allow, method)
+			}
+		}
+		sort.Strings(allow)
+		return muxResult{
+			Status:   w.Code,
+			Pattern:  w.Header().Get("X-Pattern"),
+			Params:   w.Header().Get("X-Params"),
+			Location: w.Header().Get("Location"),
+			Allow:    strings.Join(allow, ", "),
+		}
+	}
+	var list []disagreement
+	for _, request := range requests {
+		method, path := request[0], request[1]
+		d := disagreement{Method: method, Path: path,
+			Mux: serve(mux, method, path), Router: serve(&r, method, path)}
+		switch {
+		case d.Mux == d.Router:
+			continue
+		case d.Mux.Location == path+"/" || d.Mux == serve(&r, method, path+"/"):
+			// http.ServeMux перенаправляет на путь со слешем в конце или
+			// отвечает так же, как на такой путь
+			d.Kind = "slash"
+		case d.Mux.Pattern != d.Router.Pattern:
+			d.Kind = "match"
+		case d.Mux.Params != d.Router.Params:
+			d.Kind = "params"
+		default:
+			d.Kind = "status"
+		}
+		list = append(list, d)
+	}
+	return list
+}
+
+// muxRequests generates the requests from the elements of the patterns of the
+// routes and some other values.
+func muxRequests(routes []string, count int) [][2]string {
+	words := []string{"1", "x", "new"}
+	seen := make(map[string]bool)
+	for _, route := range routes {
+		_, _, path := splitServeMux(route)
+		for _, part := range strings.Split(path, "/") {
+			if part != "" && !strings.HasPrefix(part, "{") && !seen[part] {
+				seen[part] = true
+				words = append(words, part)
+			}
+		}
+	}
+	methods := []string{"GET", "GET", "GET", "POST", "DELETE"}
+	rnd := rand.New(rand.NewSource(1))
+	requests := make([][2]string, 0, count)
+	added := make(map[[2]string]bool)
+	for attempts := 0; len(requests) < count && attempts < count*10; attempts++ {
+		parts := make([]string, rnd.Intn(6))
+		for j := range parts {
+			parts[j] = words[rnd.Intn(len(words))]
+		}
+		path := "/" + strings.Join(parts, "/")
+		if len(parts) > 0 && rnd.Intn(4) == 0 {
+			path += "/"
+		}
+		request := [2]string{methods[rnd.Intn(len(methods))], path}
+		if !added[request] {
+			added[request] = true
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func TestServeMuxConformance(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Routes []string
+		// пути, которые http.ServeMux перенаправляет на путь со слешем
+		Redirects []string
+	}{
+		{
+			Name: "static",
+			Routes: []string{
+				"GET /{$}",
+				"GET /users",
+				"GET /users/new",
+				"POST /users",
+				"GET /about/{$}",
+			},
+			Redirects: []string{"/about"},
+		},
+		{
+			Name: "params",
+			Routes: []string{
+				"GET /users/{id}",
+				"DELETE /users/{id}",
+				"GET /users/{id}/posts",
+				"GET /users/{id}/posts/{post}",
+				"GET /users/new",
+				"GET /orgs/{org}/repos/{repo}",
+				"POST /orgs/{org}/repos",
+			},
+		},
+		{
+			Name: "catch-all",
+			Routes: []string{
+				"GET /files/{path...}",
+				"GET /files/public/{name}",
+				"GET /static/",
+				"GET /static/{$}",
+				"GET /static/css/{file}",
+				"POST /upload/{dir...}",
+			},
+			Redirects: []string{"/files", "/static"},
+		},
+		{
+			Name: "root",
+			Routes: []string{
+				"GET /",
+				"GET /users/{id}",
+				"GET /users/{id}/{tab...}",
+				"DELETE /users/{id}",
+			},
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			requests := muxRequests(test.Routes, 2000)
+			for _, path := range test.Redirects {
+				requests = append(requests, [2]string{"GET", path})
+			}
+			redirects := make(map[string]bool)
+			for _, d := range diffServeMux(t, test.Routes, requests) {
+				// перенаправления на путь со слешем роутер не поддерживает
+				if d.Kind == "slash" && d.Router.Status == http.StatusNotFound {
+					if d.Mux.Location != "" {
+						redirects[d.Path] = true
+					}
+					continue
+				}
+				t.Error(d)
+			}
+			for _, path := range test.Redirects {
+				if !redirects[path] {
+					t.Errorf("%v: redirect expected", path)
+				}
+			}
+		})
+	}
+}
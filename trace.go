@@ -0,0 +1,106 @@
+package router
+
+import "fmt"
+
+// Reason describes the result of checking the route by Trace.
+type Reason uint8
+
+// The results of checking the route.
+const (
+	// Matched means that the route is selected for the path.
+	Matched Reason = iota
+	// LengthMismatch means that the path is longer than the route without the
+	// catch-all parameter.
+	LengthMismatch
+	// SegmentMismatch means that the static element of the route does not
+	// match the element of the path with the same index.
+	SegmentMismatch
+	// EmptyParam means that the named parameter of the route registered with
+	// WithServeMux does not match the empty element of the path.
+	EmptyParam
+)
+
+// String returns the description of the result of checking.
+func (r Reason) String() string {
+	switch r {
+	case Matched:
+		return "matched"
+	case LengthMismatch:
+		return "length mismatch"
+	case SegmentMismatch:
+		return "static segment mismatch"
+	case EmptyParam:
+		return "empty parameter"
+	default:
+		return fmt.Sprintf("Reason(%d)", r)
+	}
+}
+
+// TraceStep describes the checking of a single route by Trace.
+type TraceStep[T any] struct {
+	Pattern string    // the pattern of the route
+	Handler T         // the handler of the route
+	Index   int       // the ordinal number of the route in order of adding
+	Kind    MatchKind // the kind of the route
+	Reason  Reason    // the result of checking
+	Segment int       // the index of mismatched path element or -1
+}
+
+// String returns the description of the step.
+func (s TraceStep[T]) String() string {
+	if s.Reason == SegmentMismatch || s.Reason == EmptyParam {
+		return fmt.Sprintf("%s: %v at %d", s.Pattern, s.Reason, s.Segment)
+	}
+	return fmt.Sprintf("%s: %v", s.Pattern, s.Reason)
+}
+
+// Trace selects the route for the path in the same way as Lookup, but returns
+// the list of all checked routes in order of checking with the reason why each
+// of them was rejected. If a route is selected, it's the last one in the list
+// with the reason Matched. Static routes are selected at once by the full
+// path, so they are not listed if there is no static route for the path.
+func (r *Table[T]) Trace(url string) []TraceStep[T] {
+	var steps []TraceStep[T]
+	r.lookup(url, func(record *record[T], reason Reason, segment int) {
+		steps = append(steps, TraceStep[T]{
+			Pattern: record.pattern(),
+			Handler: record.handler,
+			Index:   record.index,
+			Kind:    record.kind(),
+			Reason:  reason,
+			Segment: segment,
+		})
+	})
+	return steps
+}
+
+// Closest returns the route, which was rejected for the path last of all: the
+// route with the mismatched path element farthest from the beginning of the
+// path, or the first one checked among such routes. The reason of the step
+// tells whether the static element of the route differs from the path or the
+// named parameter does not accept the empty element. Returns false if a route
+// is selected for the path or there are no routes with a suitable number of
+// path elements.
+func (r *Table[T]) Closest(url string) (TraceStep[T], bool) {
+	var (
+		closest *record[T]
+		reason  Reason
+		segment = -1
+	)
+	record, _ := r.lookup(url, func(record *record[T], step Reason, index int) {
+		if (step == SegmentMismatch || step == EmptyParam) && index > segment {
+			closest, reason, segment = record, step, index
+		}
+	})
+	if record != nil || closest == nil {
+		return TraceStep[T]{}, false
+	}
+	return TraceStep[T]{
+		Pattern: closest.pattern(),
+		Handler: closest.handler,
+		Index:   closest.index,
+		Kind:    closest.kind(),
+		Reason:  reason,
+		Segment: segment,
+	}, true
+}